@@ -23,10 +23,16 @@
 package atoms
 
 import (
+	"encoding/json"
+	"strconv"
 	"sync/atomic"
 )
 
-// Boolean is atomic type
+// Boolean is atomic type. All operations are
+// performed with `sync/atomic` and therefore are
+// sequentially consistent: a write observed by
+// a read happens before that read, and all
+// operations appear in a single total order.
 type Boolean uint32
 
 // NewBoolean allocates and initialize a new
@@ -57,17 +63,45 @@ func (b *Boolean) Is(v bool) (ok bool) {
 	return atomic.LoadUint32((*uint32)(b)) == btoi(v)
 }
 
-// Flip reverses the internal value
+// Flip reverses the internal value and returns
+// the new value. It always succeeds; the second
+// result is kept for compatibility and is always
+// true. Use `Toggle` instead.
 func (b *Boolean) Flip() (bool, bool) {
+	return b.Toggle(), true
+}
+
+// Toggle reverses the internal value by performing
+// a CAS loop and returns the new value. It always
+// succeeds.
+func (b *Boolean) Toggle() (value bool) {
 	var (
-		curr uint32 = (*b).value()
-		n    uint32 = curr ^ 0x1
+		curr uint32
 	)
-	if atomic.CompareAndSwapUint32((*uint32)(b), curr, n) {
-		return itob(n), true
+	for {
+		curr = b.value()
+		if atomic.CompareAndSwapUint32((*uint32)(b), curr, curr^0x1) {
+			return itob(curr ^ 0x1)
+		}
 	}
+}
 
-	return false, false
+// Swap sets the state to `v` and
+// returns the old value.
+func (b *Boolean) Swap(v bool) (old bool) {
+	return itob(atomic.SwapUint32((*uint32)(b), btoi(v)))
+}
+
+// SetIfFalse stores true iff the current state
+// is false and returns true when succesfull.
+func (b *Boolean) SetIfFalse() (ok bool) {
+	return atomic.CompareAndSwapUint32((*uint32)(b), 0, 1)
+}
+
+// ClearIfTrue stores false iff the current state
+// is true and returns true when succesfull.
+func (b *Boolean) ClearIfTrue() (ok bool) {
+	return atomic.CompareAndSwapUint32((*uint32)(b), 1, 0)
 }
 
 // CAS performs atomic Compare-and-Swap
@@ -76,6 +110,43 @@ func (b *Boolean) CAS(o, n bool) (ok bool) {
 	return atomic.CompareAndSwapUint32((*uint32)(b), btoi(o), btoi(n))
 }
 
+// String implements `fmt.Stringer`.
+func (b *Boolean) String() string {
+	return strconv.FormatBool(b.Get())
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (b *Boolean) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (b *Boolean) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseBool(string(text))
+	if err != nil {
+		return err
+	}
+	b.Set(v)
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (b *Boolean) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (b *Boolean) UnmarshalJSON(data []byte) error {
+	var (
+		v bool
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	b.Set(v)
+	return nil
+}
+
 // btoi converts a `bool` to `uint32`.
 func btoi(b bool) uint32 {
 	if b {
@@ -22,7 +22,11 @@
 
 package atoms
 
-import "testing"
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
 
 func TestAtomicBool(t *testing.T) {
 	var (
@@ -46,3 +50,86 @@ func TestAtomicBool(t *testing.T) {
 		t.Fatal("assertion failed.")
 	}
 }
+
+func TestAtomicBoolMarshal(t *testing.T) {
+	var (
+		b Boolean
+	)
+	b.Set(true)
+	if data, err := json.Marshal(&b); err != nil || string(data) != "true" {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+	if err := b.UnmarshalText([]byte("false")); err != nil || b.Get() {
+		t.Fatal("assertion failed, invalid text decoding.", err)
+	}
+	if b.String() != "false" {
+		t.Fatal("assertion failed, expected \"false\". got", b.String())
+	}
+}
+
+func TestAtomicBoolToggle(t *testing.T) {
+	var (
+		b Boolean
+	)
+	if !b.Toggle() || b.Toggle() {
+		t.Fatal("assertion failed, expected true then false.")
+	}
+	if b.Swap(true) || !b.Swap(false) {
+		t.Fatal("assertion failed, unexpected old value.")
+	}
+	if b.ClearIfTrue() || !b.SetIfFalse() || b.SetIfFalse() || !b.Get() {
+		t.Fatal("inconsistent state.")
+	}
+	if !b.ClearIfTrue() || b.Get() {
+		t.Fatal("inconsistent state.")
+	}
+	if n, ok := b.Flip(); !n || !ok {
+		t.Fatal("assertion failed, expected Flip()==true, true.")
+	}
+}
+
+func TestAtomicBoolConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nrounds  = 1000
+	)
+	var (
+		b      *Boolean = NewBoolean()
+		owners [nrounds]Int32
+		wg     sync.WaitGroup
+	)
+	// an even number of toggles restores the state.
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < nrounds; n++ {
+				b.Toggle()
+				if _, ok := b.Flip(); !ok {
+					t.Error("inconsistent state, Flip failed.")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if b.Get() {
+		t.Fatal("inconsistent state, expected false after even toggles.")
+	}
+	// a single goroutine wins each round.
+	for n := 0; n < nrounds; n++ {
+		b.Set(false)
+		for w := 0; w < nworkers; w++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				if b.SetIfFalse() {
+					owners[n].Add(1)
+				}
+			}(n)
+		}
+		wg.Wait()
+		if owners[n].Get() != 1 {
+			t.Fatalf("inconsistent state, round %d has %d owners.", n, owners[n].Get())
+		}
+	}
+}
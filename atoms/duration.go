@@ -0,0 +1,124 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+)
+
+// Duration is atomic type. It wraps `atomic.Int64`
+// to guarantee 64-bit alignment on 32-bit platforms.
+type Duration struct {
+	v atomic.Int64
+}
+
+// NewDuration allocates and initialize a new
+// atomic duration and returns a pointer to it.
+func NewDuration() *Duration {
+	return new(Duration)
+}
+
+// Set sets the value to `v`.
+func (d *Duration) Set(v time.Duration) {
+	d.v.Store(int64(v))
+}
+
+// Get returns the value.
+func (d *Duration) Get() (value time.Duration) {
+	return time.Duration(d.v.Load())
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (d *Duration) Is(v time.Duration) (ok bool) {
+	return d.v.Load() == int64(v)
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (d *Duration) CAS(o, n time.Duration) (ok bool) {
+	return d.v.CompareAndSwap(int64(o), int64(n))
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (d *Duration) Swap(v time.Duration) (old time.Duration) {
+	return time.Duration(d.v.Swap(int64(v)))
+}
+
+// Add adds `delta` and returns the new value.
+func (d *Duration) Add(delta time.Duration) (value time.Duration) {
+	return time.Duration(d.v.Add(int64(delta)))
+}
+
+// Sub subtracts `delta` and returns the new value.
+func (d *Duration) Sub(delta time.Duration) (value time.Duration) {
+	return time.Duration(d.v.Add(-int64(delta)))
+}
+
+// String implements `fmt.Stringer`.
+func (d *Duration) String() string {
+	return d.Get().String()
+}
+
+// MarshalText implements `encoding.TextMarshaler`. The
+// value is encoded in `time.Duration` string format.
+func (d *Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Set(v)
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`. The value
+// is encoded as a string, e.g. "1.5s".
+func (d *Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`. It
+// accepts a duration string or a number of
+// nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var (
+		s  string
+		ns int64
+	)
+	if err := json.Unmarshal(data, &ns); err == nil {
+		d.Set(time.Duration(ns))
+		return nil
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
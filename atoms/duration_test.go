@@ -0,0 +1,54 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	var (
+		d Duration
+	)
+	d.Set(time.Second)
+	if !d.Is(time.Second) || d.Add(time.Second) != 2*time.Second || d.Sub(500*time.Millisecond) != 1500*time.Millisecond {
+		t.Fatal("assertion failed.")
+	}
+	if !d.CAS(1500*time.Millisecond, time.Minute) || d.CAS(0, time.Hour) || d.Swap(time.Second) != time.Minute {
+		t.Fatal("inconsistent state.")
+	}
+	if data, err := json.Marshal(&d); err != nil || string(data) != `"1s"` {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+	if err := json.Unmarshal([]byte(`"1m30s"`), &d); err != nil || d.Get() != 90*time.Second {
+		t.Fatal("assertion failed, invalid JSON decoding.", err)
+	}
+	if err := json.Unmarshal([]byte(`1000`), &d); err != nil || d.Get() != time.Microsecond {
+		t.Fatal("assertion failed, invalid JSON decoding.", err)
+	}
+	if err := d.UnmarshalText([]byte("x")); err == nil {
+		t.Fatal("assertion failed, expected error.")
+	}
+}
@@ -0,0 +1,129 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync/atomic"
+)
+
+// Float64 is atomic type. The value is stored
+// as IEEE 754 bits; note, `CAS` and `Is` compare
+// bits, hence NaN equals NaN and -0 differs from
+// +0.
+type Float64 struct {
+	v atomic.Uint64
+}
+
+// NewFloat64 allocates and initialize a new
+// atomic float64 and returns a pointer to it.
+func NewFloat64() *Float64 {
+	return new(Float64)
+}
+
+// Set sets the value to `v`.
+func (f *Float64) Set(v float64) {
+	f.v.Store(math.Float64bits(v))
+}
+
+// Get returns the value.
+func (f *Float64) Get() (value float64) {
+	return math.Float64frombits(f.v.Load())
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (f *Float64) Is(v float64) (ok bool) {
+	return f.v.Load() == math.Float64bits(v)
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (f *Float64) CAS(o, n float64) (ok bool) {
+	return f.v.CompareAndSwap(math.Float64bits(o), math.Float64bits(n))
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (f *Float64) Swap(v float64) (old float64) {
+	return math.Float64frombits(f.v.Swap(math.Float64bits(v)))
+}
+
+// Add adds `delta` by performing a CAS loop
+// and returns the new value.
+func (f *Float64) Add(delta float64) (value float64) {
+	var (
+		curr uint64
+	)
+	for {
+		curr = f.v.Load()
+		value = math.Float64frombits(curr) + delta
+		if f.v.CompareAndSwap(curr, math.Float64bits(value)) {
+			return value
+		}
+	}
+}
+
+// Sub subtracts `delta` and returns the new value.
+func (f *Float64) Sub(delta float64) (value float64) {
+	return f.Add(-delta)
+}
+
+// String implements `fmt.Stringer`.
+func (f *Float64) String() string {
+	return strconv.FormatFloat(f.Get(), 'g', -1, 64)
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (f *Float64) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (f *Float64) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseFloat(string(text), 64)
+	if err != nil {
+		return err
+	}
+	f.Set(v)
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (f *Float64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	var (
+		v float64
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	f.Set(v)
+	return nil
+}
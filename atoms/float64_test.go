@@ -0,0 +1,81 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestFloat64(t *testing.T) {
+	var (
+		f Float64
+	)
+	f.Set(1.5)
+	if !f.Is(1.5) || f.Add(1) != 2.5 || f.Sub(0.5) != 2 {
+		t.Fatal("assertion failed.")
+	}
+	if !f.CAS(2, 3.25) || f.CAS(2, 4) || f.Swap(0.5) != 3.25 {
+		t.Fatal("inconsistent state.")
+	}
+	f.Set(math.NaN())
+	if !f.Is(math.NaN()) {
+		t.Fatal("assertion failed, expected bitwise comparison.")
+	}
+	f.Set(0.25)
+	if f.String() != "0.25" {
+		t.Fatal("assertion failed, expected \"0.25\". got", f.String())
+	}
+	if err := json.Unmarshal([]byte("1e3"), &f); err != nil || f.Get() != 1000 {
+		t.Fatal("assertion failed, invalid JSON decoding.", err)
+	}
+	if data, err := json.Marshal(&f); err != nil || string(data) != "1000" {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+}
+
+func TestFloat64Concurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nrounds  = 1000
+	)
+	var (
+		f  *Float64 = NewFloat64()
+		wg sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < nrounds; n++ {
+				f.Add(0.5)
+			}
+		}()
+	}
+	wg.Wait()
+	if f.Get() != nworkers*nrounds*0.5 {
+		t.Fatalf("inconsistent state, expected %v. got %v.", nworkers*nrounds*0.5, f.Get())
+	}
+}
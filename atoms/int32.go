@@ -0,0 +1,151 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+)
+
+// Int32 is atomic type
+type Int32 int32
+
+// NewInt32 allocates and initialize a new
+// atomic int32 and returns a pointer to it.
+func NewInt32() *Int32 {
+	return new(Int32)
+}
+
+// Set sets the value to `v`.
+func (i *Int32) Set(v int32) {
+	atomic.StoreInt32((*int32)(i), v)
+}
+
+// Get returns the value.
+func (i *Int32) Get() (value int32) {
+	return atomic.LoadInt32((*int32)(i))
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (i *Int32) Is(v int32) (ok bool) {
+	return atomic.LoadInt32((*int32)(i)) == v
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (i *Int32) CAS(o, n int32) (ok bool) {
+	return atomic.CompareAndSwapInt32((*int32)(i), o, n)
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (i *Int32) Swap(v int32) (old int32) {
+	return atomic.SwapInt32((*int32)(i), v)
+}
+
+// Add adds `delta` and returns the new value.
+func (i *Int32) Add(delta int32) (value int32) {
+	return atomic.AddInt32((*int32)(i), delta)
+}
+
+// Sub subtracts `delta` and returns the new value.
+func (i *Int32) Sub(delta int32) (value int32) {
+	return atomic.AddInt32((*int32)(i), -delta)
+}
+
+// Max sets the value to `v` iff `v` is greater
+// and returns the resulting value.
+func (i *Int32) Max(v int32) (value int32) {
+	for {
+		if value = i.Get(); value >= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// Min sets the value to `v` iff `v` is smaller
+// and returns the resulting value.
+func (i *Int32) Min(v int32) (value int32) {
+	for {
+		if value = i.Get(); value <= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// And performs bitwise AND with `mask` and
+// returns the old value.
+func (i *Int32) And(mask int32) (old int32) {
+	return atomic.AndInt32((*int32)(i), mask)
+}
+
+// Or performs bitwise OR with `mask` and
+// returns the old value.
+func (i *Int32) Or(mask int32) (old int32) {
+	return atomic.OrInt32((*int32)(i), mask)
+}
+
+// String implements `fmt.Stringer`.
+func (i *Int32) String() string {
+	return strconv.FormatInt(int64(i.Get()), 10)
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (i *Int32) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (i *Int32) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseInt(string(text), 10, 32)
+	if err != nil {
+		return err
+	}
+	i.Set(int32(v))
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (i *Int32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (i *Int32) UnmarshalJSON(data []byte) error {
+	var (
+		v int32
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	i.Set(v)
+	return nil
+}
@@ -0,0 +1,154 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+)
+
+// Int64 is atomic type. It wraps `atomic.Int64` to
+// guarantee 64-bit alignment on 32-bit platforms.
+type Int64 struct {
+	v atomic.Int64
+}
+
+// NewInt64 allocates and initialize a new
+// atomic int64 and returns a pointer to it.
+func NewInt64() *Int64 {
+	return new(Int64)
+}
+
+// Set sets the value to `v`.
+func (i *Int64) Set(v int64) {
+	i.v.Store(v)
+}
+
+// Get returns the value.
+func (i *Int64) Get() (value int64) {
+	return i.v.Load()
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (i *Int64) Is(v int64) (ok bool) {
+	return i.v.Load() == v
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (i *Int64) CAS(o, n int64) (ok bool) {
+	return i.v.CompareAndSwap(o, n)
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (i *Int64) Swap(v int64) (old int64) {
+	return i.v.Swap(v)
+}
+
+// Add adds `delta` and returns the new value.
+func (i *Int64) Add(delta int64) (value int64) {
+	return i.v.Add(delta)
+}
+
+// Sub subtracts `delta` and returns the new value.
+func (i *Int64) Sub(delta int64) (value int64) {
+	return i.v.Add(-delta)
+}
+
+// Max sets the value to `v` iff `v` is greater
+// and returns the resulting value.
+func (i *Int64) Max(v int64) (value int64) {
+	for {
+		if value = i.Get(); value >= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// Min sets the value to `v` iff `v` is smaller
+// and returns the resulting value.
+func (i *Int64) Min(v int64) (value int64) {
+	for {
+		if value = i.Get(); value <= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// And performs bitwise AND with `mask` and
+// returns the old value.
+func (i *Int64) And(mask int64) (old int64) {
+	return i.v.And(mask)
+}
+
+// Or performs bitwise OR with `mask` and
+// returns the old value.
+func (i *Int64) Or(mask int64) (old int64) {
+	return i.v.Or(mask)
+}
+
+// String implements `fmt.Stringer`.
+func (i *Int64) String() string {
+	return strconv.FormatInt(int64(i.Get()), 10)
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (i *Int64) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (i *Int64) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseInt(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	i.Set(int64(v))
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (i *Int64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (i *Int64) UnmarshalJSON(data []byte) error {
+	var (
+		v int64
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	i.Set(v)
+	return nil
+}
@@ -0,0 +1,94 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestInt64(t *testing.T) {
+	var (
+		i Int64
+	)
+	i.Set(8)
+	if !i.Is(8) || i.Get() != 8 {
+		t.Fatal("assertion failed.")
+	}
+	if i.Add(4) != 12 || i.Sub(2) != 10 {
+		t.Fatal("assertion failed, invalid arithmetic.")
+	}
+	if i.Max(6) != 10 || i.Max(16) != 16 || i.Min(20) != 16 || i.Min(3) != 3 {
+		t.Fatal("assertion failed, invalid Max/Min.")
+	}
+	if i.Or(0xc) != 3 || i.And(0x5) != 0xf || i.Get() != 0x5 {
+		t.Fatal("assertion failed, invalid And/Or.")
+	}
+	if !i.CAS(5, 7) || i.CAS(5, 9) || i.Swap(1) != 7 {
+		t.Fatal("inconsistent state.")
+	}
+	if i.String() != "1" {
+		t.Fatal("assertion failed, expected \"1\". got", i.String())
+	}
+	data, err := json.Marshal(&i)
+	if err != nil || string(data) != "1" {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+	if err = json.Unmarshal([]byte("42"), &i); err != nil || i.Get() != 42 {
+		t.Fatal("assertion failed, invalid JSON decoding.", err)
+	}
+	if err = i.UnmarshalText([]byte("x")); err == nil {
+		t.Fatal("assertion failed, expected error.")
+	}
+}
+
+func TestInt64Concurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nrounds  = 1000
+	)
+	var (
+		i  *Int64 = NewInt64()
+		m  *Int64 = NewInt64()
+		wg sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for n := 0; n < nrounds; n++ {
+				i.Add(2)
+				i.Sub(1)
+				m.Max(int64(w*nrounds + n))
+			}
+		}(w)
+	}
+	wg.Wait()
+	if i.Get() != nworkers*nrounds {
+		t.Fatalf("inconsistent state, expected %d. got %d.", nworkers*nrounds, i.Get())
+	}
+	if m.Get() != nworkers*nrounds-1 {
+		t.Fatalf("inconsistent state, expected max %d. got %d.", nworkers*nrounds-1, m.Get())
+	}
+}
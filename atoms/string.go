@@ -0,0 +1,121 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// String is atomic type. The zero value
+// holds the empty string.
+type String struct {
+	v atomic.Pointer[string]
+}
+
+// NewString allocates and initialize a new
+// atomic string and returns a pointer to it.
+func NewString() *String {
+	return new(String)
+}
+
+// Set sets the value to `v`.
+func (s *String) Set(v string) {
+	s.v.Store(&v)
+}
+
+// Get returns the value.
+func (s *String) Get() (value string) {
+	if p := s.v.Load(); p != nil {
+		return *p
+	}
+	return value
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (s *String) Is(v string) (ok bool) {
+	return s.Get() == v
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (s *String) CAS(o, n string) (ok bool) {
+	var (
+		curr *string
+		cv   string
+	)
+	for {
+		if curr = s.v.Load(); curr != nil {
+			cv = *curr
+		}
+		if cv != o {
+			return false
+		}
+		if s.v.CompareAndSwap(curr, &n) {
+			return true
+		}
+	}
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (s *String) Swap(v string) (old string) {
+	if p := s.v.Swap(&v); p != nil {
+		return *p
+	}
+	return old
+}
+
+// String implements `fmt.Stringer`.
+func (s *String) String() string {
+	return s.Get()
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (s *String) MarshalText() ([]byte, error) {
+	return []byte(s.Get()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (s *String) UnmarshalText(text []byte) error {
+	s.Set(string(text))
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (s *String) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (s *String) UnmarshalJSON(data []byte) error {
+	var (
+		v string
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.Set(v)
+	return nil
+}
@@ -0,0 +1,68 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	var (
+		s String
+	)
+	if s.Get() != "" || !s.CAS("", "a") || s.CAS("", "b") {
+		t.Fatal("assertion failed, expected empty string.")
+	}
+	if !s.Is("a") || s.Swap("b") != "a" || s.String() != "b" {
+		t.Fatal("inconsistent state.")
+	}
+	if data, err := json.Marshal(&s); err != nil || string(data) != `"b"` {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+	if err := json.Unmarshal([]byte(`"c"`), &s); err != nil || !s.Is("c") {
+		t.Fatal("assertion failed, invalid JSON decoding.", err)
+	}
+}
+
+func TestStringConcurrent(t *testing.T) {
+	var (
+		s  *String = NewString()
+		wg sync.WaitGroup
+		n  Int32
+	)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.CAS("", "owner") {
+				n.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n.Get() != 1 {
+		t.Fatal("inconsistent state, expected a single owner. got", n.Get())
+	}
+}
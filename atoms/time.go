@@ -0,0 +1,129 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Time is atomic type. The zero value
+// holds the zero time.
+type Time struct {
+	v atomic.Pointer[time.Time]
+}
+
+// NewTime allocates and initialize a new
+// atomic time and returns a pointer to it.
+func NewTime() *Time {
+	return new(Time)
+}
+
+// Set sets the value to `v`.
+func (t *Time) Set(v time.Time) {
+	t.v.Store(&v)
+}
+
+// Get returns the value.
+func (t *Time) Get() (value time.Time) {
+	if p := t.v.Load(); p != nil {
+		return *p
+	}
+	return value
+}
+
+// Is compares whether the internal value
+// is equal to `v` by using `time.Time.Equal`.
+func (t *Time) Is(v time.Time) (ok bool) {
+	return t.Get().Equal(v)
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+// Values are compared by `time.Time.Equal`.
+func (t *Time) CAS(o, n time.Time) (ok bool) {
+	var (
+		curr *time.Time
+		cv   time.Time
+	)
+	for {
+		if curr = t.v.Load(); curr != nil {
+			cv = *curr
+		}
+		if !cv.Equal(o) {
+			return false
+		}
+		if t.v.CompareAndSwap(curr, &n) {
+			return true
+		}
+	}
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (t *Time) Swap(v time.Time) (old time.Time) {
+	if p := t.v.Swap(&v); p != nil {
+		return *p
+	}
+	return old
+}
+
+// String implements `fmt.Stringer`.
+func (t *Time) String() string {
+	return t.Get().String()
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+// The value is encoded in RFC 3339 format.
+func (t *Time) MarshalText() ([]byte, error) {
+	return t.Get().MarshalText()
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (t *Time) UnmarshalText(text []byte) error {
+	var (
+		v time.Time
+	)
+	if err := v.UnmarshalText(text); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (t *Time) MarshalJSON() ([]byte, error) {
+	return t.Get().MarshalJSON()
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var (
+		v time.Time
+	)
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
@@ -0,0 +1,55 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	var (
+		tm  Time
+		now time.Time = time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+	if !tm.Get().IsZero() || !tm.CAS(time.Time{}, now) {
+		t.Fatal("assertion failed, expected zero time.")
+	}
+	if !tm.Is(now.In(time.Local)) || tm.CAS(time.Time{}, now) {
+		t.Fatal("assertion failed.")
+	}
+	if old := tm.Swap(now.Add(time.Hour)); !old.Equal(now) {
+		t.Fatal("inconsistent state.")
+	}
+	data, err := json.Marshal(&tm)
+	if err != nil || string(data) != `"2018-01-02T04:04:05Z"` {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+	if err = json.Unmarshal([]byte(`"2018-01-02T03:04:05Z"`), &tm); err != nil || !tm.Is(now) {
+		t.Fatal("assertion failed, invalid JSON decoding.", err)
+	}
+	if text, _ := tm.MarshalText(); string(text) != "2018-01-02T03:04:05Z" {
+		t.Fatal("assertion failed, invalid text.", string(text))
+	}
+}
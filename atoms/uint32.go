@@ -0,0 +1,151 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+)
+
+// Uint32 is atomic type
+type Uint32 uint32
+
+// NewUint32 allocates and initialize a new
+// atomic uint32 and returns a pointer to it.
+func NewUint32() *Uint32 {
+	return new(Uint32)
+}
+
+// Set sets the value to `v`.
+func (i *Uint32) Set(v uint32) {
+	atomic.StoreUint32((*uint32)(i), v)
+}
+
+// Get returns the value.
+func (i *Uint32) Get() (value uint32) {
+	return atomic.LoadUint32((*uint32)(i))
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (i *Uint32) Is(v uint32) (ok bool) {
+	return atomic.LoadUint32((*uint32)(i)) == v
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (i *Uint32) CAS(o, n uint32) (ok bool) {
+	return atomic.CompareAndSwapUint32((*uint32)(i), o, n)
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (i *Uint32) Swap(v uint32) (old uint32) {
+	return atomic.SwapUint32((*uint32)(i), v)
+}
+
+// Add adds `delta` and returns the new value.
+func (i *Uint32) Add(delta uint32) (value uint32) {
+	return atomic.AddUint32((*uint32)(i), delta)
+}
+
+// Sub subtracts `delta` and returns the new value.
+func (i *Uint32) Sub(delta uint32) (value uint32) {
+	return atomic.AddUint32((*uint32)(i), ^(delta - 1))
+}
+
+// Max sets the value to `v` iff `v` is greater
+// and returns the resulting value.
+func (i *Uint32) Max(v uint32) (value uint32) {
+	for {
+		if value = i.Get(); value >= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// Min sets the value to `v` iff `v` is smaller
+// and returns the resulting value.
+func (i *Uint32) Min(v uint32) (value uint32) {
+	for {
+		if value = i.Get(); value <= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// And performs bitwise AND with `mask` and
+// returns the old value.
+func (i *Uint32) And(mask uint32) (old uint32) {
+	return atomic.AndUint32((*uint32)(i), mask)
+}
+
+// Or performs bitwise OR with `mask` and
+// returns the old value.
+func (i *Uint32) Or(mask uint32) (old uint32) {
+	return atomic.OrUint32((*uint32)(i), mask)
+}
+
+// String implements `fmt.Stringer`.
+func (i *Uint32) String() string {
+	return strconv.FormatUint(uint64(i.Get()), 10)
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (i *Uint32) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (i *Uint32) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseUint(string(text), 10, 32)
+	if err != nil {
+		return err
+	}
+	i.Set(uint32(v))
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (i *Uint32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (i *Uint32) UnmarshalJSON(data []byte) error {
+	var (
+		v uint32
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	i.Set(v)
+	return nil
+}
@@ -0,0 +1,154 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+)
+
+// Uint64 is atomic type. It wraps `atomic.Uint64` to
+// guarantee 64-bit alignment on 32-bit platforms.
+type Uint64 struct {
+	v atomic.Uint64
+}
+
+// NewUint64 allocates and initialize a new
+// atomic uint64 and returns a pointer to it.
+func NewUint64() *Uint64 {
+	return new(Uint64)
+}
+
+// Set sets the value to `v`.
+func (i *Uint64) Set(v uint64) {
+	i.v.Store(v)
+}
+
+// Get returns the value.
+func (i *Uint64) Get() (value uint64) {
+	return i.v.Load()
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (i *Uint64) Is(v uint64) (ok bool) {
+	return i.v.Load() == v
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (i *Uint64) CAS(o, n uint64) (ok bool) {
+	return i.v.CompareAndSwap(o, n)
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (i *Uint64) Swap(v uint64) (old uint64) {
+	return i.v.Swap(v)
+}
+
+// Add adds `delta` and returns the new value.
+func (i *Uint64) Add(delta uint64) (value uint64) {
+	return i.v.Add(delta)
+}
+
+// Sub subtracts `delta` and returns the new value.
+func (i *Uint64) Sub(delta uint64) (value uint64) {
+	return i.v.Add(^(delta - 1))
+}
+
+// Max sets the value to `v` iff `v` is greater
+// and returns the resulting value.
+func (i *Uint64) Max(v uint64) (value uint64) {
+	for {
+		if value = i.Get(); value >= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// Min sets the value to `v` iff `v` is smaller
+// and returns the resulting value.
+func (i *Uint64) Min(v uint64) (value uint64) {
+	for {
+		if value = i.Get(); value <= v {
+			return value
+		}
+		if i.CAS(value, v) {
+			return v
+		}
+	}
+}
+
+// And performs bitwise AND with `mask` and
+// returns the old value.
+func (i *Uint64) And(mask uint64) (old uint64) {
+	return i.v.And(mask)
+}
+
+// Or performs bitwise OR with `mask` and
+// returns the old value.
+func (i *Uint64) Or(mask uint64) (old uint64) {
+	return i.v.Or(mask)
+}
+
+// String implements `fmt.Stringer`.
+func (i *Uint64) String() string {
+	return strconv.FormatUint(uint64(i.Get()), 10)
+}
+
+// MarshalText implements `encoding.TextMarshaler`.
+func (i *Uint64) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (i *Uint64) UnmarshalText(text []byte) error {
+	v, err := strconv.ParseUint(string(text), 10, 64)
+	if err != nil {
+		return err
+	}
+	i.Set(uint64(v))
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (i *Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (i *Uint64) UnmarshalJSON(data []byte) error {
+	var (
+		v uint64
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	i.Set(v)
+	return nil
+}
@@ -0,0 +1,143 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// Value is atomic type holding a `T`. The zero
+// value holds the zero value of `T`. Note, `Is`
+// and `CAS` panic when `T` is not comparable.
+type Value[T any] struct {
+	v atomic.Pointer[T]
+}
+
+// NewValue allocates and initialize a new
+// atomic value and returns a pointer to it.
+func NewValue[T any]() *Value[T] {
+	return new(Value[T])
+}
+
+// Set sets the value to `v`.
+func (a *Value[T]) Set(v T) {
+	a.v.Store(&v)
+}
+
+// Get returns the value.
+func (a *Value[T]) Get() (value T) {
+	if p := a.v.Load(); p != nil {
+		return *p
+	}
+	return value
+}
+
+// Is compares whether the internal value
+// is equal to `v`.
+func (a *Value[T]) Is(v T) (ok bool) {
+	return any(a.Get()) == any(v)
+}
+
+// CAS performs atomic Compare-and-Swap
+// operation and returns true when succesfull.
+func (a *Value[T]) CAS(o, n T) (ok bool) {
+	var (
+		curr *T
+		cv   T
+	)
+	for {
+		if curr = a.v.Load(); curr != nil {
+			cv = *curr
+		}
+		if any(cv) != any(o) {
+			return false
+		}
+		if a.v.CompareAndSwap(curr, &n) {
+			return true
+		}
+	}
+}
+
+// Swap sets the value to `v` and
+// returns the old value.
+func (a *Value[T]) Swap(v T) (old T) {
+	if p := a.v.Swap(&v); p != nil {
+		return *p
+	}
+	return old
+}
+
+// String implements `fmt.Stringer`.
+func (a *Value[T]) String() string {
+	return fmt.Sprint(a.Get())
+}
+
+// MarshalText implements `encoding.TextMarshaler`. It
+// uses `T` text encoding when available and falls back
+// to JSON otherwise.
+func (a *Value[T]) MarshalText() ([]byte, error) {
+	var (
+		v T = a.Get()
+	)
+	if m, ok := any(&v).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+// It uses `T` text decoding when available and falls
+// back to JSON otherwise.
+func (a *Value[T]) UnmarshalText(text []byte) error {
+	var (
+		v T
+	)
+	if u, ok := any(&v).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText(text); err != nil {
+			return err
+		}
+		a.Set(v)
+		return nil
+	}
+	return a.UnmarshalJSON(text)
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (a *Value[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Get())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (a *Value[T]) UnmarshalJSON(data []byte) error {
+	var (
+		v T
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	a.Set(v)
+	return nil
+}
@@ -0,0 +1,66 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package atoms
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+type tstpoint struct {
+	X, Y int
+}
+
+func TestValue(t *testing.T) {
+	var (
+		v Value[tstpoint]
+	)
+	if v.Get() != (tstpoint{}) || !v.CAS(tstpoint{}, tstpoint{1, 2}) {
+		t.Fatal("assertion failed, expected zero value.")
+	}
+	if !v.Is(tstpoint{1, 2}) || v.CAS(tstpoint{}, tstpoint{3, 4}) || v.Swap(tstpoint{5, 6}) != (tstpoint{1, 2}) {
+		t.Fatal("inconsistent state.")
+	}
+	if v.String() != "{5 6}" {
+		t.Fatal("assertion failed, invalid string.", v.String())
+	}
+	if data, err := json.Marshal(&v); err != nil || string(data) != `{"X":5,"Y":6}` {
+		t.Fatal("assertion failed, invalid JSON.", string(data), err)
+	}
+	if err := v.UnmarshalText([]byte(`{"X":7,"Y":8}`)); err != nil || !v.Is(tstpoint{7, 8}) {
+		t.Fatal("assertion failed, invalid text decoding.", err)
+	}
+}
+
+func TestValueText(t *testing.T) {
+	var (
+		v *Value[netip.Addr] = NewValue[netip.Addr]()
+	)
+	if err := v.UnmarshalText([]byte("10.0.0.1")); err != nil {
+		t.Fatal("assertion failed, invalid text decoding.", err)
+	}
+	if text, err := v.MarshalText(); err != nil || string(text) != "10.0.0.1" {
+		t.Fatal("assertion failed, invalid text.", string(text), err)
+	}
+}
@@ -0,0 +1,144 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"errors"
+	"math/bits"
+	"sync/atomic"
+)
+
+// Error messages
+var (
+	FullError error = errors.New("AtomicBitArray: no clear bit")
+)
+
+// AtomicBitArray is a fixed size bit array safe for
+// concurrent use. Bits are stored in 64-bit words and
+// mutated with atomic operations, hence concurrent
+// updates to bits of the same word are never lost.
+// Bits are indexed from 0.
+type AtomicBitArray struct {
+	bits []uint64
+	size uint64
+}
+
+// NewAtomicBitArray allocates and initializes a new
+// `AtomicBitArray` holding exactly `n` bits and returns
+// a pointer to it.
+func NewAtomicBitArray(n uint64) (ba *AtomicBitArray) {
+	ba = &AtomicBitArray{
+		size: n,
+		bits: make([]uint64, (n+63)/64),
+	}
+	return ba
+}
+
+// Size returns the number of bits.
+func (ba *AtomicBitArray) Size() uint64 {
+	return ba.size
+}
+
+// Set sets `bit`.
+func (ba *AtomicBitArray) Set(bit int) error {
+	_, err := ba.TestAndSet(bit)
+	return err
+}
+
+// Clear clears `bit`.
+func (ba *AtomicBitArray) Clear(bit int) error {
+	_, err := ba.TestAndClear(bit)
+	return err
+}
+
+// IsSet returns whether `bit` is set.
+func (ba *AtomicBitArray) IsSet(bit int) (bool, error) {
+	if bit < 0 || uint64(bit) >= ba.size {
+		return false, IndexError
+	}
+	return atomic.LoadUint64(&ba.bits[bit>>6])&(1<<(uint(bit)&63)) != 0, nil
+}
+
+// TestAndSet sets `bit` and returns
+// whether it was already set.
+func (ba *AtomicBitArray) TestAndSet(bit int) (bool, error) {
+	if bit < 0 || uint64(bit) >= ba.size {
+		return false, IndexError
+	}
+	var (
+		mask uint64 = 1 << (uint(bit) & 63)
+	)
+	return atomic.OrUint64(&ba.bits[bit>>6], mask)&mask != 0, nil
+}
+
+// TestAndClear clears `bit` and returns
+// whether it was set.
+func (ba *AtomicBitArray) TestAndClear(bit int) (bool, error) {
+	if bit < 0 || uint64(bit) >= ba.size {
+		return false, IndexError
+	}
+	var (
+		mask uint64 = 1 << (uint(bit) & 63)
+	)
+	return atomic.AndUint64(&ba.bits[bit>>6], ^mask)&mask != 0, nil
+}
+
+// FindFirstZeroAndSet atomically finds the lowest clear
+// bit, sets it and returns its index. It returns
+// `FullError` when all bits are set; this makes it
+// suitable for slot allocation.
+func (ba *AtomicBitArray) FindFirstZeroAndSet() (int, error) {
+	var (
+		word uint64
+		bit  int
+		idx  uint64
+	)
+	for i := range ba.bits {
+		for {
+			word = atomic.LoadUint64(&ba.bits[i])
+			if word == ^uint64(0) {
+				break
+			}
+			bit = bits.TrailingZeros64(^word)
+			if idx = uint64(i)<<6 + uint64(bit); idx >= ba.size {
+				return -1, FullError
+			}
+			if atomic.CompareAndSwapUint64(&ba.bits[i], word, word|1<<uint(bit)) {
+				return int(idx), nil
+			}
+		}
+	}
+	return -1, FullError
+}
+
+// PopCount returns the number of set bits. Note,
+// the value is approximate under concurrent updates.
+func (ba *AtomicBitArray) PopCount() int {
+	var (
+		n int
+	)
+	for i := range ba.bits {
+		n += bits.OnesCount64(atomic.LoadUint64(&ba.bits[i]))
+	}
+	return n
+}
@@ -0,0 +1,128 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicBitArray(t *testing.T) {
+	var (
+		ba *AtomicBitArray = NewAtomicBitArray(70)
+	)
+	if ba.Size() != 70 || len(ba.bits) != 2 {
+		t.Fatalf("assertion failed, expected 70 bits in 2 words. got %d, %d.", ba.Size(), len(ba.bits))
+	}
+	if err := ba.Set(70); err != IndexError {
+		t.Errorf("expected Set(70)==IndexError; got %v.", err)
+	}
+	if err := ba.Set(-1); err != IndexError {
+		t.Errorf("expected Set(-1)==IndexError; got %v.", err)
+	}
+	if was, err := ba.TestAndSet(65); was || err != nil {
+		t.Errorf("expected TestAndSet(65)==false, nil; got %t, %v.", was, err)
+	}
+	if was, _ := ba.TestAndSet(65); !was {
+		t.Error("expected TestAndSet(65)==true.")
+	}
+	if ok, _ := ba.IsSet(65); !ok {
+		t.Error("expected IsSet(65)==true.")
+	}
+	if was, _ := ba.TestAndClear(65); !was {
+		t.Error("expected TestAndClear(65)==true.")
+	}
+	if was, _ := ba.TestAndClear(65); was {
+		t.Error("expected TestAndClear(65)==false.")
+	}
+	ba.Set(0)
+	ba.Set(1)
+	if idx, err := ba.FindFirstZeroAndSet(); idx != 2 || err != nil {
+		t.Errorf("expected FindFirstZeroAndSet()==2, nil; got %d, %v.", idx, err)
+	}
+	if n := ba.PopCount(); n != 3 {
+		t.Errorf("expected PopCount()==3; got %d.", n)
+	}
+	ba.Clear(1)
+	if ok, _ := ba.IsSet(1); ok {
+		t.Error("expected IsSet(1)==false.")
+	}
+}
+
+func TestAtomicBitArrayConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nbits    = 1000
+	)
+	var (
+		ba    *AtomicBitArray = NewAtomicBitArray(nbits)
+		slots [nworkers][]int
+		wg    sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				idx, err := ba.FindFirstZeroAndSet()
+				if err == FullError {
+					return
+				}
+				slots[w] = append(slots[w], idx)
+			}
+		}(w)
+	}
+	wg.Wait()
+	var (
+		seen [nbits]int
+	)
+	for w := range slots {
+		for _, idx := range slots[w] {
+			seen[idx]++
+		}
+	}
+	for idx, n := range seen {
+		if n != 1 {
+			t.Fatalf("inconsistent state, slot %d allocated %d times.", idx, n)
+		}
+	}
+	if n := ba.PopCount(); n != nbits {
+		t.Fatalf("expected PopCount()==%d; got %d.", nbits, n)
+	}
+	// release and re-acquire concurrently.
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for _, idx := range slots[w] {
+				if was, _ := ba.TestAndClear(idx); !was {
+					t.Errorf("inconsistent state, slot %d was not set.", idx)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if n := ba.PopCount(); n != 0 {
+		t.Fatalf("expected PopCount()==0; got %d.", n)
+	}
+}
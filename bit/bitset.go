@@ -24,13 +24,15 @@ package bit
 
 import (
 	"errors"
-	"fmt"
+	"math/bits"
 )
 
 // Constants
 const (
 	// word size
-	cWSIZE int = 8
+	cWSIZE int = 64
+	// log2 of word size
+	cWLOG int = 6
 )
 
 // Error messages
@@ -38,69 +40,74 @@ var (
 	IndexError error = errors.New("BitArray: index out of range")
 )
 
+// BitArray is a fixed size array of bits stored in
+// 64-bit words. Bits are indexed from 0 and the size
+// is rounded up to the next power of two.
 type BitArray struct {
-	bits []uint8
+	bits []uint64
 	size uint64
 }
 
+// NewBitArray allocates and initializes a new
+// `BitArray` holding at least `n` bits and returns
+// a pointer to it.
 func NewBitArray(n uint64) (ba *BitArray) {
 	ba = &BitArray{
 		size: RoundNextP2(n),
 	}
-	ba.bits = make([]uint8, ba.size/uint64(cWSIZE))
+	ba.bits = make([]uint64, nwords(ba.size))
 	return ba
 }
 
+// Size returns the number of bits.
+func (ba *BitArray) Size() uint64 {
+	return ba.size
+}
+
+// Set sets `bit`.
 func (ba *BitArray) Set(bit int) error {
-	var (
-		mask uint8 // bit mask
-		slot int   // index in slice
-		item uint8 // bit from slice
-	)
-	if uint64(bit) > ba.size {
-		goto ERROR
+	if !ba.valid(bit) {
+		return IndexError
+	}
+	mask, slot := ba.access(bit)
+	ba.bits[slot] |= mask
+	return nil
+}
+
+// Clear clears `bit`.
+func (ba *BitArray) Clear(bit int) error {
+	if !ba.valid(bit) {
+		return IndexError
 	}
-	bit -= 1
-	mask, slot, item = ba.access(bit)
-	item |= mask
-	ba.bits[slot] = item
+	mask, slot := ba.access(bit)
+	ba.bits[slot] &^= mask
 	return nil
-ERROR:
-	return IndexError
 }
 
+// Flip reverses `bit`.
 func (ba *BitArray) Flip(bit int) error {
-	var (
-		mask uint8 // bit mask
-		slot int   // index in slice
-		item uint8 // bit from slice
-	)
-	if uint64(bit) > ba.size {
-		goto ERROR
+	if !ba.valid(bit) {
+		return IndexError
 	}
-	bit -= 1
-	mask, slot, item = ba.access(bit)
-	ba.bits[slot] = ^((^item & 0xFF) ^ mask)
+	mask, slot := ba.access(bit)
+	ba.bits[slot] ^= mask
 	return nil
-ERROR:
-	return IndexError
 }
 
+// Get returns `bit` as 0x1 when set
+// and 0x0 otherwise.
 func (ba *BitArray) Get(bit int) (uint8, error) {
-	var (
-		mask uint8 // bit mask
-		item uint8 // bit from slice
-	)
-	if uint64(bit) > ba.size {
-		goto ERROR
+	if !ba.valid(bit) {
+		return 0x0, IndexError
+	}
+	mask, slot := ba.access(bit)
+	if ba.bits[slot]&mask != 0 {
+		return 0x1, nil
 	}
-	bit -= 1
-	mask, _, item = ba.access(bit)
-	return (item & mask), nil
-ERROR:
-	return 0x0, IndexError
+	return 0x0, nil
 }
 
+// IsSet returns whether `bit` is set.
 func (ba *BitArray) IsSet(bit int) (ok bool, err error) {
 	var (
 		value uint8 // bit value
@@ -112,19 +119,196 @@ func (ba *BitArray) IsSet(bit int) (ok bool, err error) {
 	return value != 0, nil
 }
 
-func (ba *BitArray) access(bit int) (uint8, int, uint8) {
+// SetAll sets all bits.
+func (ba *BitArray) SetAll() {
+	for i := range ba.bits {
+		ba.bits[i] = ^uint64(0)
+	}
+	ba.trim()
+}
+
+// ClearAll clears all bits.
+func (ba *BitArray) ClearAll() {
+	for i := range ba.bits {
+		ba.bits[i] = 0
+	}
+}
+
+// Count returns the number of set bits.
+func (ba *BitArray) Count() int {
+	var (
+		n int
+	)
+	for _, w := range ba.bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Resize changes the size to hold at least `n` bits,
+// rounded up to the next power of two. Bits beyond
+// the new size are dropped.
+func (ba *BitArray) Resize(n uint64) {
+	var (
+		size  uint64   = RoundNextP2(n)
+		words []uint64 = make([]uint64, nwords(size))
+	)
+	copy(words, ba.bits)
+	ba.bits, ba.size = words, size
+	ba.trim()
+}
+
+// Clone returns a copy of `ba`.
+func (ba *BitArray) Clone() *BitArray {
 	var (
-		mask uint8 // bit mask
-		slot int   // index in slice
-		item uint8 // bit from slice
+		c *BitArray = &BitArray{
+			bits: make([]uint64, len(ba.bits)),
+			size: ba.size,
+		}
 	)
-	mask = 0x1 << uint8(bit%cWSIZE)
-	slot = ba.findslot(bit)
-	fmt.Println("access", bit, mask, slot, item)
-	item = ba.bits[slot]
-	return mask, slot, item
+	copy(c.bits, ba.bits)
+	return c
+}
+
+// - MARK: Set-Algebra section.
+//
+// In place operations keep the size of the receiver and
+// ignore bits of `o` beyond it. Allocating operations
+// return a new `BitArray` sized to the larger operand.
+
+// UnionWith sets bits which are set in `o` ( OR ).
+func (ba *BitArray) UnionWith(o *BitArray) {
+	ba.apply(o, func(a, b uint64) uint64 { return a | b })
+}
+
+// IntersectWith clears bits which are not set in `o` ( AND ).
+func (ba *BitArray) IntersectWith(o *BitArray) {
+	ba.apply(o, func(a, b uint64) uint64 { return a & b })
+}
+
+// DifferenceWith clears bits which are set in `o` ( AND NOT ).
+func (ba *BitArray) DifferenceWith(o *BitArray) {
+	ba.apply(o, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// SymmetricDifferenceWith flips bits which are set in `o` ( XOR ).
+func (ba *BitArray) SymmetricDifferenceWith(o *BitArray) {
+	ba.apply(o, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// Union returns a new `BitArray` holding `ba` OR `o`.
+func (ba *BitArray) Union(o *BitArray) *BitArray {
+	var (
+		r *BitArray = ba.widen(o)
+	)
+	r.UnionWith(o)
+	return r
+}
+
+// Intersect returns a new `BitArray` holding `ba` AND `o`.
+func (ba *BitArray) Intersect(o *BitArray) *BitArray {
+	var (
+		r *BitArray = ba.widen(o)
+	)
+	r.IntersectWith(o)
+	return r
+}
+
+// Difference returns a new `BitArray` holding `ba` AND NOT `o`.
+func (ba *BitArray) Difference(o *BitArray) *BitArray {
+	var (
+		r *BitArray = ba.widen(o)
+	)
+	r.DifferenceWith(o)
+	return r
+}
+
+// SymmetricDifference returns a new `BitArray` holding `ba` XOR `o`.
+func (ba *BitArray) SymmetricDifference(o *BitArray) *BitArray {
+	var (
+		r *BitArray = ba.widen(o)
+	)
+	r.SymmetricDifferenceWith(o)
+	return r
+}
+
+// Equal returns whether `ba` and `o` have the
+// same size and the same set bits.
+func (ba *BitArray) Equal(o *BitArray) bool {
+	if ba.size != o.size {
+		return false
+	}
+	for i := range ba.bits {
+		if ba.bits[i] != o.bits[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns whether every bit set
+// in `ba` is also set in `o`.
+func (ba *BitArray) IsSubset(o *BitArray) bool {
+	var (
+		w uint64
+	)
+	for i := range ba.bits {
+		if w = 0; i < len(o.bits) {
+			w = o.bits[i]
+		}
+		if ba.bits[i]&^w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// apply replaces each word of `ba` with `fn` of
+// itself and the matching word of `o`.
+func (ba *BitArray) apply(o *BitArray, fn func(a, b uint64) uint64) {
+	var (
+		w uint64
+	)
+	for i := range ba.bits {
+		if w = 0; i < len(o.bits) {
+			w = o.bits[i]
+		}
+		ba.bits[i] = fn(ba.bits[i], w)
+	}
+	ba.trim()
+}
+
+// widen returns a copy of `ba` sized to
+// the larger of `ba` and `o`.
+func (ba *BitArray) widen(o *BitArray) *BitArray {
+	var (
+		r *BitArray = ba.Clone()
+	)
+	if o.size > r.size {
+		r.Resize(o.size)
+	}
+	return r
+}
+
+// trim clears unused bits of the last word.
+func (ba *BitArray) trim() {
+	if rem := ba.size & uint64(cWSIZE-1); rem != 0 {
+		ba.bits[len(ba.bits)-1] &= (1 << rem) - 1
+	}
+}
+
+// valid returns whether `bit` is in range.
+func (ba *BitArray) valid(bit int) bool {
+	return bit >= 0 && uint64(bit) < ba.size
+}
+
+// access returns the mask and word index of `bit`.
+func (ba *BitArray) access(bit int) (uint64, int) {
+	return 1 << (uint(bit) & uint(cWSIZE-1)), bit >> cWLOG
 }
 
-func (ba *BitArray) findslot(n int) int {
-	return ((n + cWSIZE - (n % cWSIZE)) / cWSIZE) - 1
+// nwords returns the number of words
+// required to hold `size` bits.
+func nwords(size uint64) int {
+	return int((size + uint64(cWSIZE) - 1) >> cWLOG)
 }
@@ -0,0 +1,260 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// - MARK: Serialization section.
+
+/*
+* binary layout ( little endian ):
+*
+*   version   : uint8  ( bitsetVERSION )
+*   word size : uint8  ( bits per word, cWSIZE )
+*   size      : uint64 ( number of bits )
+*   words     : [ceil(size/word size)]uint64
+ */
+
+const (
+	// bitsetVERSION is the version of the
+	// binary format.
+	bitsetVERSION uint8 = 0x1
+	// bitsetHDRSIZE is the size of the
+	// binary header in bytes.
+	bitsetHDRSIZE int = 10
+	// bitsetCHUNK is the number of words
+	// read at once when streaming.
+	bitsetCHUNK int = 4096
+)
+
+// Error messages
+var (
+	FormatError error = errors.New("BitArray: invalid serialized format")
+)
+
+// header returns the binary header of `ba`.
+func (ba *BitArray) header() []byte {
+	var (
+		hdr []byte = make([]byte, 2, bitsetHDRSIZE)
+	)
+	hdr[0], hdr[1] = bitsetVERSION, uint8(cWSIZE)
+	return binary.LittleEndian.AppendUint64(hdr, ba.size)
+}
+
+// parseHeader validates a binary header and
+// returns the number of bits.
+func parseHeader(hdr []byte) (uint64, error) {
+	if len(hdr) < bitsetHDRSIZE || hdr[0] != bitsetVERSION || hdr[1] != uint8(cWSIZE) {
+		return 0, FormatError
+	}
+	size := binary.LittleEndian.Uint64(hdr[2:])
+	if size&(size-1) != 0 {
+		return 0, FormatError
+	}
+	return size, nil
+}
+
+// MarshalBinary implements `encoding.BinaryMarshaler`.
+func (ba *BitArray) MarshalBinary() ([]byte, error) {
+	var (
+		data []byte = append(make([]byte, 0, bitsetHDRSIZE+8*len(ba.bits)), ba.header()...)
+	)
+	for _, w := range ba.bits {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements `encoding.BinaryUnmarshaler`.
+func (ba *BitArray) UnmarshalBinary(data []byte) error {
+	size, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	data = data[bitsetHDRSIZE:]
+	if uint64(len(data)) != 8*uint64(nwords(size)) {
+		return FormatError
+	}
+	var (
+		words []uint64 = make([]uint64, nwords(size))
+	)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	ba.bits, ba.size = words, size
+	ba.trim()
+	return nil
+}
+
+// WriteTo implements `io.WriterTo`.
+func (ba *BitArray) WriteTo(w io.Writer) (int64, error) {
+	var (
+		total int64
+		buf   []byte = ba.header()
+	)
+	for i := 0; ; {
+		n, err := w.Write(buf)
+		total += int64(n)
+		if err != nil || i >= len(ba.bits) {
+			return total, err
+		}
+		buf = buf[:0]
+		for end := min(i+bitsetCHUNK, len(ba.bits)); i < end; i++ {
+			buf = binary.LittleEndian.AppendUint64(buf, ba.bits[i])
+		}
+	}
+}
+
+// ReadFrom implements `io.ReaderFrom`. It reads
+// exactly one serialized `BitArray` from `r`.
+func (ba *BitArray) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		total int64
+		buf   []byte = make([]byte, bitsetHDRSIZE)
+		words []uint64
+	)
+	n, err := io.ReadFull(r, buf)
+	total += int64(n)
+	if err != nil {
+		return total, err
+	}
+	size, err := parseHeader(buf)
+	if err != nil {
+		return total, err
+	}
+	// words are read in chunks to avoid trusting
+	// `size` with a single large allocation.
+	for remaining := nwords(size); remaining > 0; {
+		chunk := min(remaining, bitsetCHUNK)
+		buf = buf[:0]
+		buf = append(buf, make([]byte, 8*chunk)...)
+		n, err = io.ReadFull(r, buf)
+		total += int64(n)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return total, err
+		}
+		for i := 0; i < chunk; i++ {
+			words = append(words, binary.LittleEndian.Uint64(buf[8*i:]))
+		}
+		remaining -= chunk
+	}
+	ba.bits, ba.size = words, size
+	ba.trim()
+	return total, nil
+}
+
+// MarshalText implements `encoding.TextMarshaler`
+// as base64 of the binary format.
+func (ba *BitArray) MarshalText() ([]byte, error) {
+	data, err := ba.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		text []byte = make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	)
+	base64.StdEncoding.Encode(text, data)
+	return text, nil
+}
+
+// UnmarshalText implements `encoding.TextUnmarshaler`.
+func (ba *BitArray) UnmarshalText(text []byte) error {
+	var (
+		data []byte = make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	)
+	n, err := base64.StdEncoding.Decode(data, text)
+	if err != nil {
+		return FormatError
+	}
+	return ba.UnmarshalBinary(data[:n])
+}
+
+// MarshalJSON implements `json.Marshaler` as a
+// base64 string of the binary format.
+func (ba *BitArray) MarshalJSON() ([]byte, error) {
+	text, err := ba.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (ba *BitArray) UnmarshalJSON(data []byte) error {
+	var (
+		text string
+	)
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return ba.UnmarshalText([]byte(text))
+}
+
+// String implements `fmt.Stringer` and returns
+// the bits as a string of '0' and '1', starting
+// with bit 0. It is intended for debugging.
+func (ba *BitArray) String() string {
+	var (
+		sb strings.Builder
+	)
+	sb.Grow(int(ba.size))
+	for i := 0; uint64(i) < ba.size; i++ {
+		mask, slot := ba.access(i)
+		if ba.bits[slot]&mask != 0 {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// ParseBitArray parses a string of '0' and '1' as
+// returned by `String` and returns a new `BitArray`
+// holding at least `len(s)` bits.
+func ParseBitArray(s string) (*BitArray, error) {
+	var (
+		ba *BitArray = NewBitArray(uint64(len(s)))
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '1':
+			mask, slot := ba.access(i)
+			ba.bits[slot] |= mask
+		case '0':
+		default:
+			return nil, FormatError
+		}
+	}
+	return ba, nil
+}
@@ -0,0 +1,122 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestBitArrayBinary(t *testing.T) {
+	var (
+		ba *BitArray = NewBitArray(200)
+		r  *BitArray = new(BitArray)
+	)
+	for _, i := range []int{0, 7, 64, 130, 255} {
+		ba.Set(i)
+	}
+	data, err := ba.MarshalBinary()
+	if err != nil || len(data) != bitsetHDRSIZE+8*4 || data[0] != bitsetVERSION || data[1] != 64 {
+		t.Fatalf("assertion failed, unexpected encoding %x ( %v ).", data, err)
+	}
+	if err = r.UnmarshalBinary(data); err != nil || !r.Equal(ba) {
+		t.Fatalf("inconsistent state ( %v ).", err)
+	}
+	for _, bad := range [][]byte{nil, data[:len(data)-1], append(data, 0), {0x2, 64, 0, 0, 0, 0, 0, 0, 0, 0}, {0x1, 64, 3, 0, 0, 0, 0, 0, 0, 0}} {
+		if err = r.UnmarshalBinary(bad); err != FormatError {
+			t.Errorf("expected FormatError for %x; got %v.", bad, err)
+		}
+	}
+}
+
+func TestBitArrayStream(t *testing.T) {
+	var (
+		ba  *BitArray = NewBitArray(1 << 20)
+		r   *BitArray = new(BitArray)
+		buf bytes.Buffer
+	)
+	for i := 0; i < 1<<20; i += 999 {
+		ba.Set(i)
+	}
+	n, err := ba.WriteTo(&buf)
+	if err != nil || n != int64(bitsetHDRSIZE+(1<<17)) || n != int64(buf.Len()) {
+		t.Fatalf("assertion failed, unexpected WriteTo ( %d, %v ).", n, err)
+	}
+	buf.WriteString("trailer")
+	m, err := r.ReadFrom(&buf)
+	if err != nil || m != n || buf.String() != "trailer" || !r.Equal(ba) {
+		t.Fatalf("assertion failed, unexpected ReadFrom ( %d, %v ).", m, err)
+	}
+	data, _ := ba.MarshalBinary()
+	if _, err = r.ReadFrom(bytes.NewReader(data[:len(data)-8])); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF; got %v.", err)
+	}
+	if _, err = r.ReadFrom(bytes.NewReader(nil)); err != io.EOF {
+		t.Fatalf("expected io.EOF; got %v.", err)
+	}
+}
+
+func TestBitArrayText(t *testing.T) {
+	var (
+		ba *BitArray = NewBitArray(8)
+		r  *BitArray = new(BitArray)
+	)
+	ba.Set(1)
+	ba.Set(3)
+	ba.Set(7)
+	if s := ba.String(); s != "01010001" {
+		t.Fatalf("expected String()==01010001; got %s.", s)
+	}
+	if p, err := ParseBitArray("0101"); err != nil || p.Size() != 4 || p.Count() != 2 {
+		t.Fatalf("assertion failed, unexpected ParseBitArray ( %v ).", err)
+	}
+	if p, err := ParseBitArray("0101000"); err != nil || p.Size() != 8 || p.String() != "01010000" {
+		t.Fatalf("assertion failed, unexpected ParseBitArray ( %v ).", err)
+	}
+	if _, err := ParseBitArray("01x"); err != FormatError {
+		t.Fatalf("expected FormatError; got %v.", err)
+	}
+	text, _ := ba.MarshalText()
+	if err := r.UnmarshalText(text); err != nil || !r.Equal(ba) {
+		t.Fatalf("inconsistent state ( %v ).", err)
+	}
+	if err := r.UnmarshalText([]byte("%%")); err != FormatError {
+		t.Fatalf("expected FormatError; got %v.", err)
+	}
+	var (
+		v struct {
+			Filter *BitArray `json:"filter"`
+		}
+	)
+	v.Filter = ba
+	data, err := json.Marshal(v)
+	if err != nil || !bytes.Contains(data, text) {
+		t.Fatalf("assertion failed, unexpected JSON %s ( %v ).", data, err)
+	}
+	v.Filter = nil
+	if err = json.Unmarshal(data, &v); err != nil || !v.Filter.Equal(ba) {
+		t.Fatalf("inconsistent state ( %v ).", err)
+	}
+}
@@ -30,8 +30,8 @@ import (
 func TestBitArray(t *testing.T) {
 	var (
 		ba       *BitArray = NewBitArray(32)
-		expected []uint8   = []uint8{0x83, 0x0, 0x83, 0x80}
-		setter   []int     = []int{1, 2, 8, 17, 18, 24, 32}
+		expected []uint64  = []uint64{0x80830083}
+		setter   []int     = []int{0, 1, 7, 16, 17, 23, 31}
 	)
 	if ba == nil {
 		t.Error("unable to initialize.")
@@ -51,20 +51,29 @@ func TestBitArray(t *testing.T) {
 			t.Errorf("assertion failed; iteration(%d), expected %d, got %d.", i, expected[i], ba.bits[i])
 		}
 	}
-	if err := ba.Set(33); err == nil {
-		t.Errorf("expected Set(33)!=nil; got %v.", err)
+	if err := ba.Set(32); err == nil {
+		t.Errorf("expected Set(32)!=nil; got %v.", err)
 	}
-	if _, err := ba.Get(33); err == nil {
-		t.Errorf("expected Get(33)!=nil; got %v.", err)
+	if _, err := ba.Get(32); err == nil {
+		t.Errorf("expected Get(32)!=nil; got %v.", err)
 	}
-	if value, err := ba.Get(8); err != nil {
-		t.Errorf("expected Get(8)!=nil; value %d, got %v.", value, err)
+	if value, err := ba.Get(7); err != nil || value != 1 {
+		t.Errorf("expected Get(7)==1, nil; value %d, got %v.", value, err)
 	}
-	if err := ba.Flip(15); err != nil {
-		t.Errorf("expected Flip(15)==nil; got %v.", err)
+	if err := ba.Flip(14); err != nil {
+		t.Errorf("expected Flip(14)==nil; got %v.", err)
 	}
-	if ok, err := ba.IsSet(15); ok && err != nil {
-		t.Errorf("expected IsSet(15)==true, nil; got %t, %v.", ok, err)
+	if ok, err := ba.IsSet(14); !ok || err != nil {
+		t.Errorf("expected IsSet(14)==true, nil; got %t, %v.", ok, err)
+	}
+	if err := ba.Clear(14); err != nil {
+		t.Errorf("expected Clear(14)==nil; got %v.", err)
+	}
+	if ok, _ := ba.IsSet(14); ok {
+		t.Error("expected IsSet(14)==false.")
+	}
+	if n := ba.Count(); n != len(setter) {
+		t.Errorf("expected Count()==%d; got %d.", len(setter), n)
 	}
 }
 
@@ -78,8 +87,77 @@ func TestBitArray2(t *testing.T) {
 	if ba.size != 16 {
 		t.Errorf("expected ba.size==32, got %d.", ba.size)
 	}
+	fmt.Println(ba.Set(0))
 	fmt.Println(ba.Set(1))
-	fmt.Println(ba.Set(2))
-	fmt.Println(ba.Set(16))
+	fmt.Println(ba.Set(15))
 	fmt.Println(ba)
+	ba.SetAll()
+	if n := ba.Count(); n != 16 {
+		t.Errorf("expected Count()==16 after SetAll; got %d.", n)
+	}
+	ba.ClearAll()
+	if n := ba.Count(); n != 0 {
+		t.Errorf("expected Count()==0 after ClearAll; got %d.", n)
+	}
+}
+
+func TestBitArrayAlgebra(t *testing.T) {
+	var (
+		a *BitArray = NewBitArray(128)
+		b *BitArray = NewBitArray(64)
+	)
+	for _, i := range []int{1, 3, 5, 64, 100} {
+		a.Set(i)
+	}
+	for _, i := range []int{3, 4, 5, 63} {
+		b.Set(i)
+	}
+	if u := a.Union(b); u.Size() != 128 || u.Count() != 7 {
+		t.Errorf("expected Union size 128, count 7; got %d, %d.", u.Size(), u.Count())
+	}
+	if i := a.Intersect(b); i.Count() != 2 {
+		t.Errorf("expected Intersect count 2; got %d.", i.Count())
+	}
+	if d := a.Difference(b); d.Count() != 3 {
+		t.Errorf("expected Difference count 3; got %d.", d.Count())
+	}
+	if x := a.SymmetricDifference(b); x.Count() != 5 {
+		t.Errorf("expected SymmetricDifference count 5; got %d.", x.Count())
+	}
+	if a.Count() != 5 || b.Count() != 4 {
+		t.Error("expected allocating operations to keep operands.")
+	}
+	b.IntersectWith(a)
+	if !b.IsSubset(a) || a.IsSubset(b) {
+		t.Error("expected b to be a subset of a.")
+	}
+	b.UnionWith(a)
+	if b.Count() != 3 {
+		t.Errorf("expected in place union to ignore bits beyond size; got %d.", b.Count())
+	}
+	b.SymmetricDifferenceWith(b.Clone())
+	if b.Count() != 0 {
+		t.Errorf("expected empty set; got %d.", b.Count())
+	}
+	c := a.Clone()
+	if !c.Equal(a) || c.Equal(b) {
+		t.Error("expected clone to be equal.")
+	}
+	c.DifferenceWith(a)
+	if c.Count() != 0 {
+		t.Errorf("expected empty difference; got %d.", c.Count())
+	}
+	a.Resize(65)
+	if a.Size() != 128 || a.Count() != 5 {
+		t.Errorf("expected Resize(65) to keep 128 bits; got %d, %d.", a.Size(), a.Count())
+	}
+	a.Resize(20)
+	if a.Size() != 32 || a.Count() != 3 {
+		t.Errorf("expected Resize(20) to drop bits; got %d, %d.", a.Size(), a.Count())
+	}
+	a.Resize(3)
+	a.SetAll()
+	if a.Count() != 4 {
+		t.Errorf("expected SetAll to respect size; got %d.", a.Count())
+	}
 }
@@ -0,0 +1,101 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+// Package bloom provides Bloom filters and counting
+// Bloom filters built on top of bit package.
+package bloom
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+
+	"github.com/mitghi/x/bit"
+)
+
+// Error messages
+var (
+	IncompatibleError error = errors.New("bloom: incompatible filters")
+	FormatError       error = errors.New("bloom: invalid serialized format")
+)
+
+const (
+	// fVERSION is the version of the
+	// binary format.
+	fVERSION uint8 = 0x1
+	// fHDRSIZE is the size of the binary
+	// header ( version, k ) in bytes.
+	fHDRSIZE int = 5
+)
+
+// Estimate returns the number of bits `m` and hash
+// functions `k` of a filter holding `n` items with
+// a false positive rate of `p`. `m` is rounded up to
+// the next power of two.
+func Estimate(n uint64, p float64) (m uint64, k uint32) {
+	if n == 0 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = 0.01
+	}
+	m = bit.RoundNextP2(uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))))
+	k = uint32(math.Max(1, math.Round(float64(m)/float64(n)*math.Ln2)))
+	return m, k
+}
+
+// hashes returns the two base hashes of `data`
+// used for double hashing. `h2` is odd so that
+// the probe sequence visits `k` distinct slots
+// of a power of two sized table.
+func hashes(data []byte) (h1, h2 uint64) {
+	var (
+		h   = fnv.New128a()
+		sum []byte
+	)
+	h.Write(data)
+	sum = h.Sum(nil)
+	for i := 0; i < 8; i++ {
+		h1 = h1<<8 | uint64(sum[i])
+		h2 = h2<<8 | uint64(sum[8+i])
+	}
+	return mix(h1), mix(h2) | 1
+}
+
+// mix is the 64-bit finalizer of MurmurHash3. FNV
+// leaves the low-order bits poorly mixed, while the
+// probes are masked to the low-order bits.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// location returns the `i`th probe of the
+// Kirsch-Mitzenmacher double hashing scheme
+// g_i(x) = h1(x) + i*h2(x) mod m.
+func location(h1, h2 uint64, i uint32, m uint64) int {
+	return int((h1 + uint64(i)*h2) & (m - 1))
+}
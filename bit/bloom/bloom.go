@@ -0,0 +1,237 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bloom
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/mitghi/x/bit"
+)
+
+// - MARK: Filter section.
+
+// Filter is a Bloom filter backed by a `bit.BitArray`.
+// It is not safe for concurrent mutation.
+type Filter struct {
+	bits *bit.BitArray
+	m    uint64 // number of bits
+	k    uint32 // number of hash functions
+}
+
+// New allocates and initializes a new `Filter`
+// sized for `n` items with a false positive rate
+// of `p` and returns a pointer to it.
+func New(n uint64, p float64) *Filter {
+	return NewWithSize(Estimate(n, p))
+}
+
+// NewWithSize allocates and initializes a new `Filter`
+// with at least `m` bits and `k` hash functions and
+// returns a pointer to it.
+func NewWithSize(m uint64, k uint32) *Filter {
+	var (
+		f *Filter = &Filter{
+			bits: bit.NewBitArray(max(m, 1)),
+			k:    max(k, 1),
+		}
+	)
+	f.m = f.bits.Size()
+	return f
+}
+
+// Cap returns the number of bits.
+func (f *Filter) Cap() uint64 {
+	return f.m
+}
+
+// K returns the number of hash functions.
+func (f *Filter) K() uint32 {
+	return f.k
+}
+
+// Add adds `data` to the filter.
+func (f *Filter) Add(data []byte) {
+	h1, h2 := hashes(data)
+	for i := uint32(0); i < f.k; i++ {
+		f.bits.Set(location(h1, h2, i, f.m))
+	}
+}
+
+// AddString adds `s` to the filter.
+func (f *Filter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// Test returns true when `data` may be in the
+// filter and false when it definitely is not.
+func (f *Filter) Test(data []byte) bool {
+	h1, h2 := hashes(data)
+	for i := uint32(0); i < f.k; i++ {
+		if ok, _ := f.bits.IsSet(location(h1, h2, i, f.m)); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// TestString tests `s` as in `Test`.
+func (f *Filter) TestString(s string) bool {
+	return f.Test([]byte(s))
+}
+
+// TestAndAdd adds `data` to the filter and returns
+// the result of `Test` prior to the addition.
+func (f *Filter) TestAndAdd(data []byte) bool {
+	var (
+		present bool = true
+		loc     int
+	)
+	h1, h2 := hashes(data)
+	for i := uint32(0); i < f.k; i++ {
+		loc = location(h1, h2, i, f.m)
+		if ok, _ := f.bits.IsSet(loc); !ok {
+			present = false
+			f.bits.Set(loc)
+		}
+	}
+	return present
+}
+
+// Union adds the items of `o` to `f`. Both filters
+// must have the same size and number of hash
+// functions.
+func (f *Filter) Union(o *Filter) error {
+	if f.m != o.m || f.k != o.k {
+		return IncompatibleError
+	}
+	f.bits.UnionWith(o.bits)
+	return nil
+}
+
+// Clear removes all items.
+func (f *Filter) Clear() {
+	f.bits.ClearAll()
+}
+
+// EstimatedCount returns an estimate of the number
+// of distinct items added, using the fraction of set
+// bits ( Swamidass & Baldi ). A saturated filter
+// reports the largest estimate.
+func (f *Filter) EstimatedCount() uint64 {
+	var (
+		x float64 = float64(min(uint64(f.bits.Count()), f.m-1))
+		m float64 = float64(f.m)
+	)
+	return uint64(math.Round(-m / float64(f.k) * math.Log(1-x/m)))
+}
+
+// FalsePositiveRate returns the expected false
+// positive rate for the current fill ratio.
+func (f *Filter) FalsePositiveRate() float64 {
+	return math.Pow(float64(f.bits.Count())/float64(f.m), float64(f.k))
+}
+
+// - MARK: Serialization.
+//
+// The binary format is the version ( uint8 ) and `k`
+// ( uint32, little endian ) followed by the binary
+// format of the backing `bit.BitArray`.
+
+func (f *Filter) header() []byte {
+	return binary.LittleEndian.AppendUint32([]byte{fVERSION}, f.k)
+}
+
+// parseHeader returns `k` of a binary header.
+func parseHeader(hdr []byte) (uint32, error) {
+	if len(hdr) < fHDRSIZE || hdr[0] != fVERSION {
+		return 0, FormatError
+	}
+	k := binary.LittleEndian.Uint32(hdr[1:])
+	if k == 0 {
+		return 0, FormatError
+	}
+	return k, nil
+}
+
+// MarshalBinary implements `encoding.BinaryMarshaler`.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	data, err := f.bits.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(f.header(), data...), nil
+}
+
+// UnmarshalBinary implements `encoding.BinaryUnmarshaler`.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	k, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	var (
+		bits *bit.BitArray = new(bit.BitArray)
+	)
+	if err = bits.UnmarshalBinary(data[fHDRSIZE:]); err != nil || bits.Size() == 0 {
+		return FormatError
+	}
+	f.bits, f.m, f.k = bits, bits.Size(), k
+	return nil
+}
+
+// WriteTo implements `io.WriterTo`.
+func (f *Filter) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(f.header())
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := f.bits.WriteTo(w)
+	return int64(n) + m, err
+}
+
+// ReadFrom implements `io.ReaderFrom`. It reads
+// exactly one serialized `Filter` from `r`.
+func (f *Filter) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		hdr  []byte        = make([]byte, fHDRSIZE)
+		bits *bit.BitArray = new(bit.BitArray)
+	)
+	n, err := io.ReadFull(r, hdr)
+	if err != nil {
+		return int64(n), err
+	}
+	k, err := parseHeader(hdr)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := bits.ReadFrom(r)
+	if err == bit.FormatError || (err == nil && bits.Size() == 0) {
+		err = FormatError
+	}
+	if err != nil {
+		return int64(n) + m, err
+	}
+	f.bits, f.m, f.k = bits, bits.Size(), k
+	return int64(n) + m, nil
+}
@@ -0,0 +1,121 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bloom
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	m, k := Estimate(1000, 0.01)
+	// optimal m is 9586 bits, rounded to 16384.
+	if m != 16384 || k != 11 {
+		t.Fatalf("expected Estimate(1000, 0.01)==16384, 11; got %d, %d.", m, k)
+	}
+	if m, k = Estimate(0, 2); m == 0 || k == 0 {
+		t.Fatal("assertion failed, expected non-zero size.")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	var (
+		f  *Filter = New(10000, 0.01)
+		fp int
+	)
+	for i := 0; i < 10000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	for i := 0; i < 10000; i++ {
+		if !f.TestString(strconv.Itoa(i)) {
+			t.Fatalf("assertion failed, false negative for %d.", i)
+		}
+	}
+	for i := 10000; i < 110000; i++ {
+		if f.TestString(strconv.Itoa(i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / 100000; rate > 0.01 {
+		t.Errorf("expected false positive rate <= 0.01; got %f.", rate)
+	}
+	if n := f.EstimatedCount(); n < 9800 || n > 10200 {
+		t.Errorf("expected EstimatedCount() close to 10000; got %d.", n)
+	}
+	if f.TestAndAdd([]byte("x")) || !f.TestAndAdd([]byte("x")) {
+		t.Fatal("assertion failed, unexpected TestAndAdd.")
+	}
+	f.Clear()
+	if f.TestString("1") || f.EstimatedCount() != 0 {
+		t.Fatal("assertion failed, expected empty filter.")
+	}
+}
+
+func TestFilterUnion(t *testing.T) {
+	var (
+		a *Filter = New(100, 0.01)
+		b *Filter = New(100, 0.01)
+	)
+	a.AddString("a")
+	b.AddString("b")
+	if err := a.Union(b); err != nil || !a.TestString("a") || !a.TestString("b") {
+		t.Fatalf("assertion failed, unexpected Union ( %v ).", err)
+	}
+	if err := a.Union(New(1000, 0.01)); err != IncompatibleError {
+		t.Fatalf("expected IncompatibleError; got %v.", err)
+	}
+}
+
+func TestFilterSerialize(t *testing.T) {
+	var (
+		f   *Filter = New(1000, 0.001)
+		r   *Filter = new(Filter)
+		buf bytes.Buffer
+	)
+	for i := 0; i < 1000; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+	data, _ := f.MarshalBinary()
+	if err := r.UnmarshalBinary(data); err != nil || r.K() != f.K() || r.Cap() != f.Cap() {
+		t.Fatalf("inconsistent state ( %v ).", err)
+	}
+	for i := 0; i < 1000; i++ {
+		if !r.TestString(strconv.Itoa(i)) {
+			t.Fatalf("assertion failed, false negative for %d.", i)
+		}
+	}
+	n, err := f.WriteTo(&buf)
+	if err != nil || n != int64(len(data)) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("assertion failed, unexpected WriteTo ( %d, %v ).", n, err)
+	}
+	r = new(Filter)
+	if m, err := r.ReadFrom(&buf); err != nil || m != n || r.EstimatedCount() != f.EstimatedCount() {
+		t.Fatalf("assertion failed, unexpected ReadFrom ( %d, %v ).", m, err)
+	}
+	for _, bad := range [][]byte{nil, data[:len(data)-1], {0x2, 1, 0, 0, 0}, append([]byte{0x1, 0, 0, 0, 0}, data[fHDRSIZE:]...)} {
+		if err := r.UnmarshalBinary(bad); err != FormatError {
+			t.Errorf("expected FormatError; got %v.", err)
+		}
+	}
+}
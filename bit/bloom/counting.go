@@ -0,0 +1,210 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bloom
+
+import (
+	"encoding/binary"
+
+	"github.com/mitghi/x/bit"
+)
+
+// - MARK: Counting Filter section.
+
+const (
+	// cBITS is the width of a counter.
+	cBITS uint = 4
+	// cMAX is the saturated counter value.
+	cMAX uint64 = 1<<cBITS - 1
+	// cPERWORD is the number of counters per word.
+	cPERWORD int = 64 / int(cBITS)
+)
+
+// CountingFilter is a Bloom filter using 4-bit counters
+// instead of bits, which allows removing items. Counters
+// saturate at 15 and are never decremented afterwards,
+// so removing does not introduce false negatives. It is
+// not safe for concurrent mutation.
+type CountingFilter struct {
+	counters []uint64
+	m        uint64 // number of counters
+	k        uint32 // number of hash functions
+}
+
+// NewCounting allocates and initializes a new
+// `CountingFilter` sized for `n` items with a false
+// positive rate of `p` and returns a pointer to it.
+func NewCounting(n uint64, p float64) *CountingFilter {
+	return NewCountingWithSize(Estimate(n, p))
+}
+
+// NewCountingWithSize allocates and initializes a new
+// `CountingFilter` with at least `m` counters and `k`
+// hash functions and returns a pointer to it.
+func NewCountingWithSize(m uint64, k uint32) *CountingFilter {
+	var (
+		f *CountingFilter = &CountingFilter{
+			m: bit.RoundNextP2(max(m, 1)),
+			k: max(k, 1),
+		}
+	)
+	f.counters = make([]uint64, (f.m+uint64(cPERWORD)-1)/uint64(cPERWORD))
+	return f
+}
+
+// Cap returns the number of counters.
+func (f *CountingFilter) Cap() uint64 {
+	return f.m
+}
+
+// K returns the number of hash functions.
+func (f *CountingFilter) K() uint32 {
+	return f.k
+}
+
+// counter returns the value of counter `i`.
+func (f *CountingFilter) counter(i int) uint64 {
+	return f.counters[i/cPERWORD] >> (uint(i%cPERWORD) * cBITS) & cMAX
+}
+
+// adjust adds `delta` ( +1 or -1 ) to counter `i`
+// unless it is saturated.
+func (f *CountingFilter) adjust(i int, delta int) {
+	var (
+		c uint64 = f.counter(i)
+	)
+	if c == cMAX || (c == 0 && delta < 0) {
+		return
+	}
+	var (
+		shift uint = uint(i%cPERWORD) * cBITS
+	)
+	f.counters[i/cPERWORD] &^= cMAX << shift
+	f.counters[i/cPERWORD] |= uint64(int(c)+delta) << shift
+}
+
+// Add adds `data` to the filter.
+func (f *CountingFilter) Add(data []byte) {
+	h1, h2 := hashes(data)
+	for i := uint32(0); i < f.k; i++ {
+		f.adjust(location(h1, h2, i, f.m), 1)
+	}
+}
+
+// Remove removes `data` from the filter and returns
+// true when succesfull. It returns false and leaves
+// the filter unchanged when `data` is definitely not
+// in the filter. Removing an item that was never
+// added may introduce false negatives.
+func (f *CountingFilter) Remove(data []byte) bool {
+	if !f.Test(data) {
+		return false
+	}
+	h1, h2 := hashes(data)
+	for i := uint32(0); i < f.k; i++ {
+		f.adjust(location(h1, h2, i, f.m), -1)
+	}
+	return true
+}
+
+// Test returns true when `data` may be in the
+// filter and false when it definitely is not.
+func (f *CountingFilter) Test(data []byte) bool {
+	h1, h2 := hashes(data)
+	for i := uint32(0); i < f.k; i++ {
+		if f.counter(location(h1, h2, i, f.m)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd adds `data` to the filter and returns
+// the result of `Test` prior to the addition.
+func (f *CountingFilter) TestAndAdd(data []byte) bool {
+	var (
+		present bool = f.Test(data)
+	)
+	f.Add(data)
+	return present
+}
+
+// Clear removes all items.
+func (f *CountingFilter) Clear() {
+	clear(f.counters)
+}
+
+// Filter returns a `Filter` holding the same
+// items, with a bit set for every non-zero
+// counter.
+func (f *CountingFilter) Filter() *Filter {
+	var (
+		r *Filter = NewWithSize(f.m, f.k)
+	)
+	for i := 0; uint64(i) < f.m; i++ {
+		if f.counter(i) != 0 {
+			r.bits.Set(i)
+		}
+	}
+	return r
+}
+
+// - MARK: Serialization.
+//
+// The binary format is the version ( uint8 ), `k`
+// ( uint32 ) and `m` ( uint64 ) followed by the
+// counter words, all little endian.
+
+// MarshalBinary implements `encoding.BinaryMarshaler`.
+func (f *CountingFilter) MarshalBinary() ([]byte, error) {
+	var (
+		data []byte = make([]byte, 0, fHDRSIZE+8+8*len(f.counters))
+	)
+	data = append(data, fVERSION)
+	data = binary.LittleEndian.AppendUint32(data, f.k)
+	data = binary.LittleEndian.AppendUint64(data, f.m)
+	for _, w := range f.counters {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements `encoding.BinaryUnmarshaler`.
+func (f *CountingFilter) UnmarshalBinary(data []byte) error {
+	k, err := parseHeader(data)
+	if err != nil || len(data) < fHDRSIZE+8 {
+		return FormatError
+	}
+	m := binary.LittleEndian.Uint64(data[fHDRSIZE:])
+	data = data[fHDRSIZE+8:]
+	if m == 0 || m&(m-1) != 0 || uint64(len(data)) != 8*((m+uint64(cPERWORD)-1)/uint64(cPERWORD)) {
+		return FormatError
+	}
+	var (
+		counters []uint64 = make([]uint64, len(data)/8)
+	)
+	for i := range counters {
+		counters[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	f.counters, f.m, f.k = counters, m, k
+	return nil
+}
@@ -0,0 +1,123 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bloom
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCountingFilter(t *testing.T) {
+	var (
+		f *CountingFilter = NewCounting(1000, 0.01)
+	)
+	for i := 0; i < 1000; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+	for i := 0; i < 1000; i += 2 {
+		if !f.Remove([]byte(strconv.Itoa(i))) {
+			t.Fatalf("assertion failed, unable to remove %d.", i)
+		}
+	}
+	var (
+		fp int
+	)
+	for i := 0; i < 1000; i++ {
+		ok := f.Test([]byte(strconv.Itoa(i)))
+		if i%2 == 1 && !ok {
+			t.Fatalf("assertion failed, false negative for %d.", i)
+		}
+		if i%2 == 0 && ok {
+			fp++
+		}
+	}
+	if fp > 20 {
+		t.Errorf("expected few false positives after Remove; got %d.", fp)
+	}
+	if f.Remove([]byte("absent")) && !f.Test([]byte("absent")) {
+		t.Fatal("inconsistent state.")
+	}
+	if f.TestAndAdd([]byte("y")) || !f.TestAndAdd([]byte("y")) {
+		t.Fatal("assertion failed, unexpected TestAndAdd.")
+	}
+	plain := f.Filter()
+	for i := 1; i < 1000; i += 2 {
+		if !plain.Test([]byte(strconv.Itoa(i))) {
+			t.Fatalf("assertion failed, false negative for %d.", i)
+		}
+	}
+	f.Clear()
+	if f.Test([]byte("1")) {
+		t.Fatal("assertion failed, expected empty filter.")
+	}
+}
+
+func TestCountingSaturation(t *testing.T) {
+	var (
+		f    *CountingFilter = NewCountingWithSize(64, 3)
+		item []byte          = []byte("item")
+	)
+	for i := 0; i < 20; i++ {
+		f.Add(item)
+	}
+	for i := 0; i < 20; i++ {
+		f.Remove(item)
+	}
+	// saturated counters are sticky.
+	if !f.Test(item) {
+		t.Fatal("assertion failed, expected saturated counters.")
+	}
+	f.Clear()
+	f.Add(item)
+	f.Add(item)
+	f.Remove(item)
+	if !f.Test(item) {
+		t.Fatal("assertion failed, false negative.")
+	}
+	f.Remove(item)
+	if f.Test(item) {
+		t.Fatal("assertion failed, expected removed item.")
+	}
+}
+
+func TestCountingSerialize(t *testing.T) {
+	var (
+		f *CountingFilter = NewCounting(100, 0.01)
+		r *CountingFilter = new(CountingFilter)
+	)
+	for i := 0; i < 100; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+	data, _ := f.MarshalBinary()
+	if err := r.UnmarshalBinary(data); err != nil || r.Cap() != f.Cap() || r.K() != f.K() {
+		t.Fatalf("inconsistent state ( %v ).", err)
+	}
+	for i := 0; i < 100; i++ {
+		if !r.Test([]byte(strconv.Itoa(i))) {
+			t.Fatalf("assertion failed, false negative for %d.", i)
+		}
+	}
+	if err := r.UnmarshalBinary(data[:len(data)-1]); err != FormatError {
+		t.Fatalf("expected FormatError; got %v.", err)
+	}
+}
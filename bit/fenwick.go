@@ -0,0 +1,198 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+// - MARK: Fenwick section.
+
+// Number is the set of types summed by
+// Fenwick trees.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// lowbit returns the lowest set bit of `i`.
+func lowbit(i int) int {
+	return int(LowestSet(uint64(i)))
+}
+
+// Fenwick is a binary indexed tree over `n` values
+// supporting point updates and prefix / range sums
+// in O(log n). Indices are 0-based; out of range
+// indices panic with `IndexError`.
+type Fenwick[T Number] struct {
+	tree []T // 1-based
+}
+
+// NewFenwick allocates and initializes a new
+// `Fenwick` tree of `n` zero values and returns
+// a pointer to it.
+func NewFenwick[T Number](n int) *Fenwick[T] {
+	return &Fenwick[T]{tree: make([]T, max(n, 0)+1)}
+}
+
+// FenwickFrom returns a new `Fenwick` tree holding
+// `values`, built in O(n).
+func FenwickFrom[T Number](values []T) *Fenwick[T] {
+	var (
+		f *Fenwick[T] = NewFenwick[T](len(values))
+	)
+	copy(f.tree[1:], values)
+	for i := 1; i < len(f.tree); i++ {
+		if j := i + lowbit(i); j < len(f.tree) {
+			f.tree[j] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// Len returns the number of values.
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add adds `delta` to value `i`.
+func (f *Fenwick[T]) Add(i int, delta T) {
+	if i < 0 || i >= f.Len() {
+		panic(IndexError)
+	}
+	for i++; i < len(f.tree); i += lowbit(i) {
+		f.tree[i] += delta
+	}
+}
+
+// Set sets value `i` to `v`.
+func (f *Fenwick[T]) Set(i int, v T) {
+	f.Add(i, v-f.Get(i))
+}
+
+// Get returns value `i`.
+func (f *Fenwick[T]) Get(i int) T {
+	return f.RangeSum(i, i+1)
+}
+
+// PrefixSum returns the sum of values in [0, i).
+func (f *Fenwick[T]) PrefixSum(i int) T {
+	var (
+		sum T
+	)
+	if i < 0 || i > f.Len() {
+		panic(IndexError)
+	}
+	for ; i > 0; i = int(ClearLowestSet(uint64(i))) {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of values in [lo, hi).
+func (f *Fenwick[T]) RangeSum(lo, hi int) T {
+	if lo > hi {
+		panic(IndexError)
+	}
+	return f.PrefixSum(hi) - f.PrefixSum(lo)
+}
+
+// LowerBound returns the smallest `i` such that
+// PrefixSum(i+1) >= `sum`, or Len() when there is
+// none. Values must be non-negative.
+func (f *Fenwick[T]) LowerBound(sum T) int {
+	var (
+		pos int
+		n   int = f.Len()
+	)
+	if n == 0 {
+		return 0
+	}
+	for step, _ := PrevPow2(uint64(n)); step > 0; step >>= 1 {
+		if next := pos + int(step); next <= n && f.tree[next] < sum {
+			pos = next
+			sum -= f.tree[next]
+		}
+	}
+	return pos
+}
+
+// Fenwick2D is a two-dimensional binary indexed tree
+// over a `rows` x `cols` grid supporting point updates
+// and rectangle sums in O(log rows * log cols).
+type Fenwick2D[T Number] struct {
+	rows, cols int
+	tree       []T // 1-based, row major
+}
+
+// NewFenwick2D allocates and initializes a new
+// `Fenwick2D` tree of zero values and returns
+// a pointer to it.
+func NewFenwick2D[T Number](rows, cols int) *Fenwick2D[T] {
+	rows, cols = max(rows, 0), max(cols, 0)
+	return &Fenwick2D[T]{
+		rows: rows,
+		cols: cols,
+		tree: make([]T, (rows+1)*(cols+1)),
+	}
+}
+
+// Dims returns the number of rows and columns.
+func (f *Fenwick2D[T]) Dims() (int, int) {
+	return f.rows, f.cols
+}
+
+// Add adds `delta` to value (`r`, `c`).
+func (f *Fenwick2D[T]) Add(r, c int, delta T) {
+	if r < 0 || r >= f.rows || c < 0 || c >= f.cols {
+		panic(IndexError)
+	}
+	for i := r + 1; i <= f.rows; i += lowbit(i) {
+		for j := c + 1; j <= f.cols; j += lowbit(j) {
+			f.tree[i*(f.cols+1)+j] += delta
+		}
+	}
+}
+
+// PrefixSum returns the sum of values in
+// [0, r) x [0, c).
+func (f *Fenwick2D[T]) PrefixSum(r, c int) T {
+	var (
+		sum T
+	)
+	if r < 0 || r > f.rows || c < 0 || c > f.cols {
+		panic(IndexError)
+	}
+	for i := r; i > 0; i = int(ClearLowestSet(uint64(i))) {
+		for j := c; j > 0; j = int(ClearLowestSet(uint64(j))) {
+			sum += f.tree[i*(f.cols+1)+j]
+		}
+	}
+	return sum
+}
+
+// RangeSum returns the sum of values in
+// [r0, r1) x [c0, c1).
+func (f *Fenwick2D[T]) RangeSum(r0, c0, r1, c1 int) T {
+	if r0 > r1 || c0 > c1 {
+		panic(IndexError)
+	}
+	return f.PrefixSum(r1, c1) - f.PrefixSum(r0, c1) - f.PrefixSum(r1, c0) + f.PrefixSum(r0, c0)
+}
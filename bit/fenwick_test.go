@@ -0,0 +1,116 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFenwick(t *testing.T) {
+	var (
+		rng    *rand.Rand = rand.New(rand.NewSource(1))
+		values []int64    = make([]int64, 1000)
+	)
+	for i := range values {
+		values[i] = rng.Int63n(100)
+	}
+	f := FenwickFrom(values)
+	for step := 0; step < 2000; step++ {
+		i := rng.Intn(len(values))
+		switch rng.Intn(3) {
+		case 0:
+			d := rng.Int63n(100)
+			values[i] += d
+			f.Add(i, d)
+		case 1:
+			values[i] = rng.Int63n(100)
+			f.Set(i, values[i])
+		default:
+			lo, hi := min(i, rng.Intn(len(values)+1)), max(i, rng.Intn(len(values)+1))
+			var (
+				want int64
+			)
+			for _, v := range values[lo:hi] {
+				want += v
+			}
+			if got := f.RangeSum(lo, hi); got != want {
+				t.Fatalf("expected RangeSum(%d, %d)==%d; got %d.", lo, hi, want, got)
+			}
+		}
+	}
+	var (
+		prefix int64
+	)
+	for i, v := range values {
+		if f.Get(i) != v {
+			t.Fatalf("expected Get(%d)==%d; got %d.", i, v, f.Get(i))
+		}
+		if v > 0 {
+			if got := f.LowerBound(prefix + 1); got != i {
+				t.Fatalf("expected LowerBound(%d)==%d; got %d.", prefix+1, i, got)
+			}
+		}
+		prefix += v
+	}
+	if f.LowerBound(prefix+1) != f.Len() || f.PrefixSum(f.Len()) != prefix {
+		t.Fatal("inconsistent state.")
+	}
+	defer func() {
+		if recover() != IndexError {
+			t.Fatal("assertion failed, expected IndexError.")
+		}
+	}()
+	f.Add(f.Len(), 1)
+}
+
+func TestFenwick2D(t *testing.T) {
+	var (
+		rng  *rand.Rand          = rand.New(rand.NewSource(2))
+		f    *Fenwick2D[float64] = NewFenwick2D[float64](13, 29)
+		grid [13][29]float64
+	)
+	for step := 0; step < 500; step++ {
+		r, c := rng.Intn(13), rng.Intn(29)
+		grid[r][c] += float64(step)
+		f.Add(r, c, float64(step))
+	}
+	for step := 0; step < 500; step++ {
+		r0, c0 := rng.Intn(14), rng.Intn(30)
+		r1, c1 := r0+rng.Intn(14-r0), c0+rng.Intn(30-c0)
+		var (
+			want float64
+		)
+		for r := r0; r < r1; r++ {
+			for c := c0; c < c1; c++ {
+				want += grid[r][c]
+			}
+		}
+		if got := f.RangeSum(r0, c0, r1, c1); got != want {
+			t.Fatalf("expected RangeSum(%d, %d, %d, %d)==%f; got %f.", r0, c0, r1, c1, want, got)
+		}
+	}
+	if r, c := f.Dims(); r != 13 || c != 29 {
+		t.Fatal("inconsistent state.")
+	}
+}
@@ -0,0 +1,129 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// - MARK: Iteration section.
+
+// NextSet returns the index of the first set bit
+// at or after `from` along with true. It returns
+// false when there is no such bit.
+func (ba *BitArray) NextSet(from int) (int, bool) {
+	if from < 0 {
+		from = 0
+	}
+	if uint64(from) >= ba.size {
+		return -1, false
+	}
+	var (
+		slot int    = from >> cWLOG
+		word uint64 = ba.bits[slot] >> (uint(from) & uint(cWSIZE-1))
+	)
+	if word != 0 {
+		return from + bits.TrailingZeros64(word), true
+	}
+	for slot++; slot < len(ba.bits); slot++ {
+		if ba.bits[slot] != 0 {
+			return slot<<cWLOG + bits.TrailingZeros64(ba.bits[slot]), true
+		}
+	}
+	return -1, false
+}
+
+// NextClear returns the index of the first clear
+// bit at or after `from` along with true. It
+// returns false when there is no such bit.
+func (ba *BitArray) NextClear(from int) (int, bool) {
+	if from < 0 {
+		from = 0
+	}
+	if uint64(from) >= ba.size {
+		return -1, false
+	}
+	var (
+		slot int    = from >> cWLOG
+		word uint64 = ^ba.bits[slot] >> (uint(from) & uint(cWSIZE-1))
+		idx  int    = -1
+	)
+	if word != 0 {
+		idx = from + bits.TrailingZeros64(word)
+	} else {
+		for slot++; slot < len(ba.bits); slot++ {
+			if ba.bits[slot] != ^uint64(0) {
+				idx = slot<<cWLOG + bits.TrailingZeros64(^ba.bits[slot])
+				break
+			}
+		}
+	}
+	if idx < 0 || uint64(idx) >= ba.size {
+		return -1, false
+	}
+	return idx, true
+}
+
+// PrevSet returns the index of the last set bit
+// at or before `from` along with true. It returns
+// false when there is no such bit.
+func (ba *BitArray) PrevSet(from int) (int, bool) {
+	if from < 0 || ba.size == 0 {
+		return -1, false
+	}
+	if uint64(from) >= ba.size {
+		from = int(ba.size - 1)
+	}
+	var (
+		slot int    = from >> cWLOG
+		word uint64 = ba.bits[slot] << (uint(cWSIZE-1) - uint(from)&uint(cWSIZE-1))
+	)
+	if word != 0 {
+		return from - bits.LeadingZeros64(word), true
+	}
+	for slot--; slot >= 0; slot-- {
+		if ba.bits[slot] != 0 {
+			return slot<<cWLOG + cWSIZE - 1 - bits.LeadingZeros64(ba.bits[slot]), true
+		}
+	}
+	return -1, false
+}
+
+// All returns an iterator over indexes
+// of set bits in ascending order.
+func (ba *BitArray) All() iter.Seq[int] {
+	return ba.Range(0, int(ba.size))
+}
+
+// Range returns an iterator over indexes of set
+// bits in range [`lo`, `hi`) in ascending order.
+func (ba *BitArray) Range(lo, hi int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := ba.NextSet(lo); ok && i < hi; i, ok = ba.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
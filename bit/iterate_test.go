@@ -0,0 +1,76 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitArrayIterate(t *testing.T) {
+	var (
+		ba  *BitArray = NewBitArray(256)
+		set []int     = []int{0, 3, 63, 64, 130, 255}
+	)
+	for _, i := range set {
+		ba.Set(i)
+	}
+	if got := slices.Collect(ba.All()); !slices.Equal(got, set) {
+		t.Errorf("expected All()==%v; got %v.", set, got)
+	}
+	if got := slices.Collect(ba.Range(3, 130)); !slices.Equal(got, []int{3, 63, 64}) {
+		t.Errorf("expected Range(3, 130)==[3 63 64]; got %v.", got)
+	}
+	for _, c := range []struct{ from, want int }{{-4, 0}, {1, 3}, {4, 63}, {65, 130}, {255, 255}} {
+		if i, ok := ba.NextSet(c.from); !ok || i != c.want {
+			t.Errorf("expected NextSet(%d)==%d; got %d, %t.", c.from, c.want, i, ok)
+		}
+	}
+	if i, ok := ba.NextSet(256); ok {
+		t.Errorf("expected NextSet(256)==false; got %d.", i)
+	}
+	for _, c := range []struct{ from, want int }{{2, 0}, {62, 3}, {64, 64}, {129, 64}, {1000, 255}} {
+		if i, ok := ba.PrevSet(c.from); !ok || i != c.want {
+			t.Errorf("expected PrevSet(%d)==%d; got %d, %t.", c.from, c.want, i, ok)
+		}
+	}
+	if i, ok := ba.PrevSet(-1); ok {
+		t.Errorf("expected PrevSet(-1)==false; got %d.", i)
+	}
+	if i, ok := ba.NextClear(63); !ok || i != 65 {
+		t.Errorf("expected NextClear(63)==65; got %d, %t.", i, ok)
+	}
+	ba.SetAll()
+	if i, ok := ba.NextClear(0); ok {
+		t.Errorf("expected NextClear(0)==false; got %d.", i)
+	}
+	ba.Clear(200)
+	if i, ok := ba.NextClear(0); !ok || i != 200 {
+		t.Errorf("expected NextClear(0)==200; got %d, %t.", i, ok)
+	}
+	for i := range ba.All() {
+		if i >= 10 {
+			break
+		}
+	}
+}
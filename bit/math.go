@@ -22,14 +22,19 @@
 
 package bit
 
+import (
+	"errors"
+	"math/bits"
+)
+
 type blktable []int
 
+// Error messages
 var (
-	mDeBruijnBitPosition [32]int = [32]int{
-		0, 9, 1, 10, 13, 21, 2, 29, 11, 14, 16, 18, 22, 25, 3, 30,
-		8, 12, 20, 28, 15, 17, 24, 7, 19, 27, 23, 6, 26, 5, 4, 31,
-	}
+	EALIGN error = errors.New("bit: alignment is not a power of two.")
+)
 
+var (
 	blocks blktable = blktable{
 		8, 8, 8,
 		16, 32, 64,
@@ -49,20 +54,34 @@ func (b blktable) bin(num int) int {
 }
 
 func (b blktable) size(num int) int {
-	return b[int(b.bin(num))]
+	return b.nbalgn(uint64(num))
 }
 
+// lgb2 returns the table index of `num`, i.e.
+// max(ceil(log2(num)) - 2, 0).
 func (b blktable) lgb2(num uint64) int {
-	return mDeBruijnBitPosition[int(uint64(uint32(RoundPrevP2(num)-1)*(uint32)(0x07C4ACDD))>>27)]
+	return max(Log2Ceil(num)-2, 0)
 }
 
+// nbalgn returns the block size of `num`. Sizes beyond
+// the table keep doubling; it returns 0 when the size
+// overflows `int`.
 func (b blktable) nbalgn(num uint64) int {
-	if num == 0 {
-		return blocks[0]
+	var (
+		i int = b.lgb2(num)
+	)
+	if i < len(b) {
+		return b[i]
+	}
+	if i+1 < bits.UintSize-1 {
+		return 1 << (i + 1)
 	}
-	return blocks[b.lgb2(num)]
+	return 0
 }
 
+// RoundNextP2 returns the smallest power of two greater
+// than or equal to `num`. It returns 0 for 0 and when
+// the result overflows; see `NextPow2`.
 func RoundNextP2(num uint64) uint64 {
 	num--
 	num |= num >> 1
@@ -75,6 +94,9 @@ func RoundNextP2(num uint64) uint64 {
 	return num
 }
 
+// RoundPrevP2 returns the largest power of two strictly
+// less than `num` ( 1 for 1 and 2, 0 for 0 and for values
+// above 2^63 ); see `PrevPow2`.
 func RoundPrevP2(num uint64) uint64 {
 	num--
 	num |= num >> 1
@@ -87,14 +109,184 @@ func RoundPrevP2(num uint64) uint64 {
 	return num - (num >> 1)
 }
 
+// Reverse returns `num` with its bits in reversed order.
+func Reverse(num uint64) uint64 {
+	return bits.Reverse64(num)
+}
+
+// NAlignBits returns the block size used to align `num`.
 func NAlignBits(num uint64) int {
 	return blocks.nbalgn(num)
 }
 
+// Lgb2 returns the block table index of `num`,
+// i.e. max(ceil(log2(num)) - 2, 0).
 func Lgb2(num uint64) int {
 	return blocks.lgb2(num)
 }
 
+// - MARK: Bit-Math section.
+
+// Log2Floor returns floor(log2(num)) and -1 for 0.
+func Log2Floor(num uint64) int {
+	return bits.Len64(num) - 1
+}
+
+// Log2Ceil returns ceil(log2(num)) and 0 for 0.
+func Log2Ceil(num uint64) int {
+	if num <= 1 {
+		return 0
+	}
+	return bits.Len64(num - 1)
+}
+
+// IsPow2 returns whether `num` is a power of two.
+func IsPow2(num uint64) bool {
+	return num != 0 && num&(num-1) == 0
+}
+
+// NextPow2 returns the smallest power of two greater
+// than or equal to `num` along with true. It returns
+// false when the result overflows ( num > 2^63 ).
+func NextPow2(num uint64) (uint64, bool) {
+	if num <= 1 {
+		return 1, true
+	}
+	var (
+		n int = bits.Len64(num - 1)
+	)
+	if n == 64 {
+		return 0, false
+	}
+	return 1 << n, true
+}
+
+// PrevPow2 returns the largest power of two less
+// than or equal to `num` along with true. It returns
+// false for 0.
+func PrevPow2(num uint64) (uint64, bool) {
+	if num == 0 {
+		return 0, false
+	}
+	return 1 << (bits.Len64(num) - 1), true
+}
+
+// AlignUp rounds `num` up to a multiple of `align`
+// along with true. It returns false when the result
+// overflows. It panics when `align` is not a power
+// of two.
+func AlignUp(num, align uint64) (uint64, bool) {
+	if !IsPow2(align) {
+		panic(EALIGN)
+	}
+	r := (num + align - 1) &^ (align - 1)
+	return r, r >= num
+}
+
+// AlignDown rounds `num` down to a multiple of `align`.
+// It panics when `align` is not a power of two.
+func AlignDown(num, align uint64) uint64 {
+	if !IsPow2(align) {
+		panic(EALIGN)
+	}
+	return num &^ (align - 1)
+}
+
+// LowestSet returns the lowest set bit of `num`
+// as a mask, or 0 for 0.
+func LowestSet(num uint64) uint64 {
+	return num & -num
+}
+
+// ClearLowestSet returns `num` with its lowest
+// set bit cleared.
+func ClearLowestSet(num uint64) uint64 {
+	return num & (num - 1)
+}
+
+// RotateLeft returns `num` rotated left by `k` bits;
+// a negative `k` rotates right.
+func RotateLeft(num uint64, k int) uint64 {
+	return bits.RotateLeft64(num, k)
+}
+
+// RotateRight returns `num` rotated right by `k` bits;
+// a negative `k` rotates left.
+func RotateRight(num uint64, k int) uint64 {
+	return bits.RotateLeft64(num, -k)
+}
+
+// - MARK: Morton section.
+
+// spread2 inserts a zero bit after each of the
+// low 32 bits of `x`.
+func spread2(x uint64) uint64 {
+	x &= 0x00000000FFFFFFFF
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// compact2 is the inverse of `spread2`.
+func compact2(x uint64) uint64 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x>>4) & 0x00FF00FF00FF00FF
+	x = (x | x>>8) & 0x0000FFFF0000FFFF
+	x = (x | x>>16) & 0x00000000FFFFFFFF
+	return x
+}
+
+// spread3 inserts two zero bits after each of the
+// low 21 bits of `x`.
+func spread3(x uint64) uint64 {
+	x &= 0x1FFFFF
+	x = (x | x<<32) & 0x001F00000000FFFF
+	x = (x | x<<16) & 0x001F0000FF0000FF
+	x = (x | x<<8) & 0x100F00F00F00F00F
+	x = (x | x<<4) & 0x10C30C30C30C30C3
+	x = (x | x<<2) & 0x1249249249249249
+	return x
+}
+
+// compact3 is the inverse of `spread3`.
+func compact3(x uint64) uint64 {
+	x &= 0x1249249249249249
+	x = (x | x>>2) & 0x10C30C30C30C30C3
+	x = (x | x>>4) & 0x100F00F00F00F00F
+	x = (x | x>>8) & 0x001F0000FF0000FF
+	x = (x | x>>16) & 0x001F00000000FFFF
+	x = (x | x>>32) & 0x1FFFFF
+	return x
+}
+
+// MortonEncode2 interleaves the bits of `x` and `y`
+// into a Z-order code; bit i of `x` becomes bit 2i.
+func MortonEncode2(x, y uint32) uint64 {
+	return spread2(uint64(x)) | spread2(uint64(y))<<1
+}
+
+// MortonDecode2 is the inverse of `MortonEncode2`.
+func MortonDecode2(code uint64) (x, y uint32) {
+	return uint32(compact2(code)), uint32(compact2(code >> 1))
+}
+
+// MortonEncode3 interleaves the low 21 bits of `x`,
+// `y` and `z` into a Z-order code; bit i of `x`
+// becomes bit 3i.
+func MortonEncode3(x, y, z uint32) uint64 {
+	return spread3(uint64(x)) | spread3(uint64(y))<<1 | spread3(uint64(z))<<2
+}
+
+// MortonDecode3 is the inverse of `MortonEncode3`.
+func MortonDecode3(code uint64) (x, y, z uint32) {
+	return uint32(compact3(code)), uint32(compact3(code >> 1)), uint32(compact3(code >> 2))
+}
+
 // DEV SECTION
 
 func findslot(n int, wsize int, capacity int) int {
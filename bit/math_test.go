@@ -22,7 +22,12 @@
 
 package bit
 
-import "testing"
+import (
+	"math"
+	"math/bits"
+	"testing"
+	"testing/quick"
+)
 
 func TestMath(t *testing.T) {
 	const n = 16
@@ -40,3 +45,138 @@ func TestMath(t *testing.T) {
 	t.Log(RoundPrevP2(32))
 	t.Log(findslot(32, 8, 32))
 }
+
+func TestReverse(t *testing.T) {
+	if Reverse(1) != 1<<63 || Reverse(0x6) != 0x6<<60 || Reverse(Reverse(0xdeadbeef)) != 0xdeadbeef {
+		t.Fatal("assertion failed, invalid bit reversal.")
+	}
+}
+
+// lgb2DeBruijn is the former 32-bit implementation
+// of `Lgb2`, kept as a reference.
+func lgb2DeBruijn(num uint64) int {
+	var (
+		table [32]int = [32]int{
+			0, 9, 1, 10, 13, 21, 2, 29, 11, 14, 16, 18, 22, 25, 3, 30,
+			8, 12, 20, 28, 15, 17, 24, 7, 19, 27, 23, 6, 26, 5, 4, 31,
+		}
+	)
+	return table[int(uint64(uint32(RoundPrevP2(num)-1)*(uint32)(0x07C4ACDD))>>27)]
+}
+
+func TestLgb2(t *testing.T) {
+	for num := uint64(1); num < 1<<32; num = num*3/2 + 1 {
+		if Lgb2(num) != lgb2DeBruijn(num) {
+			t.Fatalf("expected Lgb2(%d)==%d; got %d.", num, lgb2DeBruijn(num), Lgb2(num))
+		}
+	}
+	if Lgb2(1<<40) != 38 || Lgb2(1<<40+1) != 39 || Lgb2(math.MaxUint64) != 62 {
+		t.Fatal("assertion failed, invalid 64-bit Lgb2.")
+	}
+	if NAlignBits(0) != 8 || NAlignBits(17) != 16 || NAlignBits(1<<40) != 1<<39 || NAlignBits(math.MaxUint64) != 0 {
+		t.Fatal("assertion failed, invalid NAlignBits.")
+	}
+}
+
+func TestLog2(t *testing.T) {
+	if err := quick.Check(func(num uint64) bool {
+		return Log2Floor(num) == bits.Len64(num)-1 &&
+			(num <= 1 || Log2Ceil(num) == bits.Len64(num-1)) &&
+			IsPow2(num) == (bits.OnesCount64(num) == 1)
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		num         uint64
+		floor, ceil int
+	}{{0, -1, 0}, {1, 0, 0}, {2, 1, 1}, {3, 1, 2}, {1 << 63, 63, 63}, {1<<63 + 1, 63, 64}} {
+		if Log2Floor(c.num) != c.floor || Log2Ceil(c.num) != c.ceil {
+			t.Errorf("expected Log2Floor(%d)==%d, Log2Ceil==%d; got %d, %d.", c.num, c.floor, c.ceil, Log2Floor(c.num), Log2Ceil(c.num))
+		}
+	}
+}
+
+func TestPow2(t *testing.T) {
+	if err := quick.Check(func(num uint64) bool {
+		next, ok := NextPow2(num)
+		prev, pok := PrevPow2(num)
+		if num > 1<<63 {
+			return !ok && pok && prev == 1<<63
+		}
+		return ok && IsPow2(next) && next >= num && next>>1 < max(num, 1) &&
+			(num == 0 || (pok && IsPow2(prev) && prev <= num && num-prev < prev))
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := NextPow2(1 << 63); !ok || v != 1<<63 {
+		t.Fatal("assertion failed, invalid NextPow2(2^63).")
+	}
+	if _, ok := NextPow2(1<<63 + 1); ok {
+		t.Fatal("assertion failed, expected overflow.")
+	}
+	if _, ok := PrevPow2(0); ok {
+		t.Fatal("assertion failed, expected PrevPow2(0) failure.")
+	}
+	for num, prev := range map[uint64]uint64{0: 0, 1: 1, 2: 1, 3: 2, 5: 4, 8: 4, 1 << 63: 1 << 62, 1<<63 + 1: 0} {
+		if RoundPrevP2(num) != prev {
+			t.Fatalf("assertion failed, expected RoundPrevP2(%d)==%d. got %d.", num, prev, RoundPrevP2(num))
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	if err := quick.Check(func(num uint64, shift uint8) bool {
+		align := uint64(1) << (shift % 64)
+		up, ok := AlignUp(num, align)
+		down := AlignDown(num, align)
+		if !ok {
+			return num > math.MaxUint64-align+1
+		}
+		return up%align == 0 && up >= num && up-num < align &&
+			down%align == 0 && down <= num && num-down < align
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := AlignUp(math.MaxUint64, 8); ok {
+		t.Fatal("assertion failed, expected overflow.")
+	}
+	defer func() {
+		if recover() != EALIGN {
+			t.Fatal("assertion failed, expected EALIGN panic.")
+		}
+	}()
+	AlignDown(10, 3)
+}
+
+func TestRotate(t *testing.T) {
+	if err := quick.Check(func(num uint64, k int8) bool {
+		return RotateLeft(num, int(k)) == bits.RotateLeft64(num, int(k)) &&
+			RotateRight(RotateLeft(num, int(k)), int(k)) == num &&
+			Reverse(num) == bits.Reverse64(num) &&
+			LowestSet(num) == num&(1<<bits.TrailingZeros64(num)) &&
+			ClearLowestSet(num) == num^LowestSet(num)
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMorton(t *testing.T) {
+	if err := quick.Check(func(x, y, z uint32) bool {
+		var (
+			code2, code3 uint64
+		)
+		for i := 0; i < 32; i++ {
+			code2 |= uint64(x>>i&1)<<(2*i) | uint64(y>>i&1)<<(2*i+1)
+		}
+		for i := 0; i < 21; i++ {
+			code3 |= uint64(x>>i&1)<<(3*i) | uint64(y>>i&1)<<(3*i+1) | uint64(z>>i&1)<<(3*i+2)
+		}
+		dx, dy := MortonDecode2(code2)
+		ex, ey, ez := MortonDecode3(code3)
+		return MortonEncode2(x, y) == code2 && dx == x && dy == y &&
+			MortonEncode3(x, y, z) == code3 &&
+			ex == x&0x1FFFFF && ey == y&0x1FFFFF && ez == z&0x1FFFFF
+	}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
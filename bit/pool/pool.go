@@ -0,0 +1,155 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+// Package pool provides a `sync.Pool` backed buffer
+// pool using the size classes of bit package.
+package pool
+
+import (
+	"sync"
+
+	"github.com/mitghi/x/atoms"
+	"github.com/mitghi/x/bit"
+)
+
+// ClassStats is a snapshot of the counters
+// of a single size class.
+type ClassStats struct {
+	Class  bit.SizeClass
+	Size   int    // class size in bytes
+	Gets   uint64 // buffers handed out
+	Puts   uint64 // buffers returned
+	Allocs uint64 // buffers allocated on a pool miss
+}
+
+// InUse returns the number of buffers handed
+// out and not yet returned.
+func (s ClassStats) InUse() uint64 {
+	return s.Gets - s.Puts
+}
+
+// class is a per-class pool.
+type class struct {
+	pool   sync.Pool
+	gets   atoms.Uint64
+	puts   atoms.Uint64
+	allocs atoms.Uint64
+}
+
+// Pool hands out `[]byte` buffers with capacity
+// rounded up to their size class and recycles
+// them through one `sync.Pool` per class. It is
+// safe for concurrent use.
+type Pool struct {
+	classes   []*class
+	oversized atoms.Uint64
+	discarded atoms.Uint64
+}
+
+// New allocates and initializes a new `Pool` for
+// buffers up to `maxSize` bytes and returns a pointer
+// to it. Larger requests are allocated directly.
+func New(maxSize int) *Pool {
+	var (
+		p *Pool = &Pool{}
+	)
+	last, ok := bit.ClassOf(maxSize)
+	if !ok {
+		last = bit.SizeClass(bit.NumSizeClasses() - 1)
+	}
+	p.classes = make([]*class, last+1)
+	for i := range p.classes {
+		var (
+			c    *class = &class{}
+			size int    = bit.SizeClass(i).Size()
+		)
+		c.pool.New = func() any {
+			c.allocs.Add(1)
+			b := make([]byte, size)
+			return &b
+		}
+		p.classes[i] = c
+	}
+	return p
+}
+
+// MaxSize returns the size of the largest
+// pooled class.
+func (p *Pool) MaxSize() int {
+	return bit.SizeClass(len(p.classes) - 1).Size()
+}
+
+// Get returns a buffer of length `size` whose
+// capacity is the size of its class. The content
+// of recycled buffers is not cleared.
+func (p *Pool) Get(size int) []byte {
+	c, ok := bit.ClassOf(size)
+	if !ok || int(c) >= len(p.classes) {
+		p.oversized.Add(1)
+		return make([]byte, size)
+	}
+	p.classes[c].gets.Add(1)
+	return (*p.classes[c].pool.Get().(*[]byte))[:size]
+}
+
+// Put returns `b` to the pool. Buffers whose
+// capacity is not a pooled class size, such as
+// oversized buffers, are discarded.
+func (p *Pool) Put(b []byte) {
+	c, ok := bit.ClassOf(cap(b))
+	if !ok || int(c) >= len(p.classes) || c.Size() != cap(b) {
+		p.discarded.Add(1)
+		return
+	}
+	b = b[:cap(b)]
+	p.classes[c].puts.Add(1)
+	p.classes[c].pool.Put(&b)
+}
+
+// Stats returns a snapshot of the per-class counters.
+func (p *Pool) Stats() []ClassStats {
+	var (
+		stats []ClassStats = make([]ClassStats, len(p.classes))
+	)
+	for i, c := range p.classes {
+		stats[i] = ClassStats{
+			Class:  bit.SizeClass(i),
+			Size:   bit.SizeClass(i).Size(),
+			Gets:   c.gets.Get(),
+			Puts:   c.puts.Get(),
+			Allocs: c.allocs.Get(),
+		}
+	}
+	return stats
+}
+
+// Oversized returns the number of requests
+// larger than `MaxSize`.
+func (p *Pool) Oversized() uint64 {
+	return p.oversized.Get()
+}
+
+// Discarded returns the number of buffers
+// rejected by `Put`.
+func (p *Pool) Discarded() uint64 {
+	return p.discarded.Get()
+}
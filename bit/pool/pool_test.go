@@ -0,0 +1,90 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package pool
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	var (
+		p *Pool = New(4096)
+	)
+	if p.MaxSize() != 4096 || len(p.Stats()) != 10 {
+		t.Fatalf("expected MaxSize()==4096 over 10 classes; got %d, %d.", p.MaxSize(), len(p.Stats()))
+	}
+	b := p.Get(1500)
+	if len(b) != 1500 || cap(b) != 2048 {
+		t.Fatalf("expected len 1500, cap 2048; got %d, %d.", len(b), cap(b))
+	}
+	p.Put(b)
+	p.Put(make([]byte, 100))
+	if big := p.Get(5000); len(big) != 5000 {
+		t.Fatal("assertion failed, invalid oversized buffer.")
+	}
+	p.Put(make([]byte, 8192))
+	s := p.Stats()[8]
+	if s.Size != 2048 || s.Gets != 1 || s.Puts != 1 || s.Allocs != 1 || s.InUse() != 0 {
+		t.Fatalf("inconsistent state %+v.", s)
+	}
+	if p.Oversized() != 1 || p.Discarded() != 2 {
+		t.Fatalf("expected Oversized()==1, Discarded()==2; got %d, %d.", p.Oversized(), p.Discarded())
+	}
+	if New(1<<40).MaxSize() != 1<<32 {
+		t.Fatal("assertion failed, expected largest class.")
+	}
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	var (
+		p  *Pool = New(1 << 16)
+		wg sync.WaitGroup
+	)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b := p.Get((g*997 + i*31) % (1 << 16))
+				for j := range b {
+					b[j] = byte(g)
+				}
+				p.Put(b)
+			}
+		}(g)
+	}
+	wg.Wait()
+	var (
+		gets uint64
+	)
+	for _, s := range p.Stats() {
+		if s.InUse() != 0 || s.Allocs > s.Gets {
+			t.Fatalf("inconsistent state %+v.", s)
+		}
+		gets += s.Gets
+	}
+	if gets != 8000 {
+		t.Fatalf("expected 8000 gets; got %d.", gets)
+	}
+}
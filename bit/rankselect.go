@@ -0,0 +1,181 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// - MARK: Rank/Select section.
+
+const (
+	// rsBLOCKW is the number of words per block.
+	rsBLOCKW int = 8
+	// rsSUPERB is the number of blocks per superblock.
+	rsSUPERB int = 128
+)
+
+// RankSelect is a read-only succinct bitvector answering
+// rank and select queries over a snapshot of a `BitArray`.
+//
+// Ones are counted for every superblock ( 2^16 bits, as
+// uint64 ) and for every block ( 512 bits, as uint16
+// relative to its superblock ), an overhead of about 3%.
+// Rank runs in constant time and select performs a binary
+// search over the samples followed by a scan of a single
+// block.
+type RankSelect struct {
+	bits   []uint64
+	size   uint64
+	ones   uint64
+	supers []uint64 // ones before each superblock
+	blocks []uint16 // ones before each block within its superblock
+}
+
+// NewRankSelect allocates and initializes a new
+// `RankSelect` over a copy of `ba` and returns a
+// pointer to it.
+func NewRankSelect(ba *BitArray) *RankSelect {
+	var (
+		rs *RankSelect = &RankSelect{
+			bits: append([]uint64(nil), ba.bits...),
+			size: ba.size,
+		}
+		nblocks int = (len(ba.bits) + rsBLOCKW - 1) / rsBLOCKW
+		local   uint64
+		c       uint64
+	)
+	rs.blocks = make([]uint16, nblocks)
+	rs.supers = make([]uint64, (nblocks+rsSUPERB-1)/rsSUPERB)
+	for b := 0; b < nblocks; b++ {
+		if b%rsSUPERB == 0 {
+			rs.supers[b/rsSUPERB], local = rs.ones, 0
+		}
+		rs.blocks[b] = uint16(local)
+		for _, w := range rs.bits[b*rsBLOCKW : min((b+1)*rsBLOCKW, len(rs.bits))] {
+			c = uint64(bits.OnesCount64(w))
+			rs.ones, local = rs.ones+c, local+c
+		}
+	}
+	return rs
+}
+
+// Size returns the number of bits.
+func (rs *RankSelect) Size() uint64 {
+	return rs.size
+}
+
+// Ones returns the number of set bits.
+func (rs *RankSelect) Ones() uint64 {
+	return rs.ones
+}
+
+// Rank1 returns the number of set bits before
+// position `i`, i.e. in [0, i). `i` is clamped
+// to [0, Size()].
+func (rs *RankSelect) Rank1(i int) uint64 {
+	if i <= 0 {
+		return 0
+	}
+	if uint64(i) >= rs.size {
+		return rs.ones
+	}
+	var (
+		w int    = i >> cWLOG
+		b int    = w / rsBLOCKW
+		r uint64 = rs.supers[b/rsSUPERB] + uint64(rs.blocks[b])
+	)
+	for j := b * rsBLOCKW; j < w; j++ {
+		r += uint64(bits.OnesCount64(rs.bits[j]))
+	}
+	return r + uint64(bits.OnesCount64(rs.bits[w]&(1<<(uint(i)&uint(cWSIZE-1))-1)))
+}
+
+// Rank0 returns the number of clear bits before
+// position `i`, i.e. in [0, i). `i` is clamped
+// to [0, Size()].
+func (rs *RankSelect) Rank0(i int) uint64 {
+	i = max(0, min(i, int(rs.size)))
+	return uint64(i) - rs.Rank1(i)
+}
+
+// Select1 returns the position of the `k`th set bit
+// ( 0-based ) along with true. It returns false when
+// there are at most `k` set bits.
+func (rs *RankSelect) Select1(k uint64) (int, bool) {
+	if k >= rs.ones {
+		return -1, false
+	}
+	return rs.find(k, func(w uint64) uint64 { return w },
+		func(s int) uint64 { return rs.supers[s] },
+		func(b int) uint64 { return uint64(rs.blocks[b]) })
+}
+
+// Select0 returns the position of the `k`th clear bit
+// ( 0-based ) along with true. It returns false when
+// there are at most `k` clear bits.
+func (rs *RankSelect) Select0(k uint64) (int, bool) {
+	if k >= rs.size-rs.ones {
+		return -1, false
+	}
+	return rs.find(k, func(w uint64) uint64 { return ^w },
+		func(s int) uint64 { return uint64(s*rsSUPERB*rsBLOCKW*cWSIZE) - rs.supers[s] },
+		func(b int) uint64 {
+			return uint64((b%rsSUPERB)*rsBLOCKW*cWSIZE) - uint64(rs.blocks[b])
+		})
+}
+
+// find locates the `k`th bit counted by `super` and
+// `block` samples, where `word` maps a word to the bits
+// of interest. The caller guarantees that `k` is in range.
+func (rs *RankSelect) find(k uint64, word func(uint64) uint64, super func(int) uint64, block func(int) uint64) (int, bool) {
+	var (
+		s  int = sort.Search(len(rs.supers), func(s int) bool { return super(s) > k }) - 1
+		lo int = s * rsSUPERB
+		hi int = min(lo+rsSUPERB, len(rs.blocks))
+	)
+	k -= super(s)
+	b := lo + sort.Search(hi-lo, func(j int) bool { return block(lo+j) > k }) - 1
+	k -= block(b)
+	for j := b * rsBLOCKW; j < len(rs.bits); j++ {
+		var (
+			w uint64 = word(rs.bits[j])
+			c uint64 = uint64(bits.OnesCount64(w))
+		)
+		if k < c {
+			return j<<cWLOG + selectWord(w, int(k)), true
+		}
+		k -= c
+	}
+	return -1, false
+}
+
+// selectWord returns the position of the `k`th
+// set bit of `w` ( 0-based ).
+func selectWord(w uint64, k int) int {
+	for ; k > 0; k-- {
+		w &= w - 1
+	}
+	return bits.TrailingZeros64(w)
+}
@@ -0,0 +1,93 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRankSelect(t *testing.T) {
+	var (
+		rng *rand.Rand = rand.New(rand.NewSource(1))
+	)
+	for _, c := range []struct {
+		size    uint64
+		density int
+	}{{0, 2}, {16, 2}, {1000, 2}, {1 << 18, 100}, {1 << 18, 1}, {1 << 17, 0}} {
+		var (
+			ba          *BitArray = NewBitArray(c.size)
+			ones, zeros []int
+		)
+		for i := 0; uint64(i) < ba.Size(); i++ {
+			if c.density == 0 || rng.Intn(c.density) == 0 {
+				ba.Set(i)
+			}
+		}
+		rs := NewRankSelect(ba)
+		if rs.Size() != ba.Size() || rs.Ones() != uint64(ba.Count()) {
+			t.Fatalf("inconsistent state for size %d.", c.size)
+		}
+		for i := 0; uint64(i) < ba.Size(); i++ {
+			if uint64(len(ones)) != rs.Rank1(i) || uint64(len(zeros)) != rs.Rank0(i) {
+				t.Fatalf("expected Rank1(%d)==%d, Rank0(%d)==%d; got %d, %d.", i, len(ones), i, len(zeros), rs.Rank1(i), rs.Rank0(i))
+			}
+			if ok, _ := ba.IsSet(i); ok {
+				ones = append(ones, i)
+			} else {
+				zeros = append(zeros, i)
+			}
+		}
+		if rs.Rank1(int(ba.Size())+5) != uint64(len(ones)) || rs.Rank0(-1) != 0 {
+			t.Fatal("assertion failed, expected clamped rank.")
+		}
+		for k, want := range ones {
+			if got, ok := rs.Select1(uint64(k)); !ok || got != want {
+				t.Fatalf("expected Select1(%d)==%d; got %d, %t.", k, want, got, ok)
+			}
+		}
+		for k, want := range zeros {
+			if got, ok := rs.Select0(uint64(k)); !ok || got != want {
+				t.Fatalf("expected Select0(%d)==%d; got %d, %t.", k, want, got, ok)
+			}
+		}
+		if _, ok := rs.Select1(uint64(len(ones))); ok {
+			t.Fatal("assertion failed, expected out of range Select1.")
+		}
+		if _, ok := rs.Select0(uint64(len(zeros))); ok {
+			t.Fatal("assertion failed, expected out of range Select0.")
+		}
+	}
+}
+
+func TestRankSelectSnapshot(t *testing.T) {
+	var (
+		ba *BitArray = NewBitArray(64)
+	)
+	ba.Set(3)
+	rs := NewRankSelect(ba)
+	ba.Set(1)
+	if rs.Rank1(64) != 1 {
+		t.Fatal("assertion failed, expected independent snapshot.")
+	}
+}
@@ -0,0 +1,327 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"iter"
+	"sort"
+)
+
+// - MARK: Roaring section.
+
+// Roaring is a compressed bitmap of `uint32` values.
+// Values are partitioned by their high 16 bits into
+// containers holding the low 16 bits as a sorted
+// array ( sparse ), a bitmap ( dense ) or a list of
+// runs ( clustered ). Containers are converted as
+// their cardinality changes; run containers are
+// produced by `RunOptimize`.
+type Roaring struct {
+	keys       []uint16
+	containers []container
+}
+
+// NewRoaring allocates and initializes a new
+// empty `Roaring` bitmap and returns a pointer
+// to it.
+func NewRoaring() *Roaring {
+	return &Roaring{}
+}
+
+// RoaringOf returns a new `Roaring` bitmap
+// holding `values`.
+func RoaringOf(values ...uint32) *Roaring {
+	var (
+		rb *Roaring = NewRoaring()
+	)
+	for _, v := range values {
+		rb.Add(v)
+	}
+	return rb
+}
+
+// split returns the container key and low bits of `x`.
+func split(x uint32) (uint16, uint16) {
+	return uint16(x >> 16), uint16(x)
+}
+
+// index returns the position of container `key`
+// and whether it exists.
+func (rb *Roaring) index(key uint16) (int, bool) {
+	i := sort.Search(len(rb.keys), func(i int) bool { return rb.keys[i] >= key })
+	return i, i < len(rb.keys) && rb.keys[i] == key
+}
+
+// Add adds `x` to the bitmap.
+func (rb *Roaring) Add(x uint32) {
+	hi, lo := split(x)
+	i, ok := rb.index(hi)
+	if ok {
+		rb.containers[i] = rb.containers[i].add(lo)
+		return
+	}
+	rb.keys = append(rb.keys, 0)
+	copy(rb.keys[i+1:], rb.keys[i:])
+	rb.keys[i] = hi
+	rb.containers = append(rb.containers, nil)
+	copy(rb.containers[i+1:], rb.containers[i:])
+	rb.containers[i] = &arrayContainer{content: []uint16{lo}}
+}
+
+// Remove removes `x` from the bitmap.
+func (rb *Roaring) Remove(x uint32) {
+	hi, lo := split(x)
+	i, ok := rb.index(hi)
+	if !ok {
+		return
+	}
+	rb.containers[i] = rb.containers[i].remove(lo)
+	if rb.containers[i].cardinality() == 0 {
+		rb.keys = append(rb.keys[:i], rb.keys[i+1:]...)
+		rb.containers = append(rb.containers[:i], rb.containers[i+1:]...)
+	}
+}
+
+// Contains returns true when `x` is in the bitmap.
+func (rb *Roaring) Contains(x uint32) bool {
+	hi, lo := split(x)
+	i, ok := rb.index(hi)
+	return ok && rb.containers[i].contains(lo)
+}
+
+// Cardinality returns the number of values.
+func (rb *Roaring) Cardinality() uint64 {
+	var (
+		n uint64
+	)
+	for _, c := range rb.containers {
+		n += uint64(c.cardinality())
+	}
+	return n
+}
+
+// IsEmpty returns true when the bitmap holds
+// no values.
+func (rb *Roaring) IsEmpty() bool {
+	return len(rb.containers) == 0
+}
+
+// Clear removes all values.
+func (rb *Roaring) Clear() {
+	rb.keys, rb.containers = nil, nil
+}
+
+// Clone returns a deep copy of the bitmap.
+func (rb *Roaring) Clone() *Roaring {
+	var (
+		r *Roaring = &Roaring{
+			keys:       append([]uint16(nil), rb.keys...),
+			containers: make([]container, len(rb.containers)),
+		}
+	)
+	for i, c := range rb.containers {
+		r.containers[i] = c.clone()
+	}
+	return r
+}
+
+// Equal returns true when `rb` and `other`
+// hold the same values.
+func (rb *Roaring) Equal(other *Roaring) bool {
+	if len(rb.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range rb.keys {
+		if key != other.keys[i] || rb.containers[i].cardinality() != other.containers[i].cardinality() {
+			return false
+		}
+		var (
+			oc container = other.containers[i]
+		)
+		if !rb.containers[i].each(oc.contains) {
+			return false
+		}
+	}
+	return true
+}
+
+// RunOptimize converts every container to its
+// smallest representation, using run containers
+// for clustered values.
+func (rb *Roaring) RunOptimize() {
+	for i, c := range rb.containers {
+		rb.containers[i] = optimize(c)
+	}
+}
+
+// - MARK: Rank/Select section.
+
+// Rank returns the number of values smaller
+// than or equal to `x`.
+func (rb *Roaring) Rank(x uint32) uint64 {
+	var (
+		n uint64
+	)
+	hi, lo := split(x)
+	for i, key := range rb.keys {
+		if key > hi {
+			break
+		}
+		if key == hi {
+			return n + uint64(rb.containers[i].rank(lo))
+		}
+		n += uint64(rb.containers[i].cardinality())
+	}
+	return n
+}
+
+// Select returns the `i`th smallest value ( 0-based )
+// along with true. It returns false when `i` is not
+// less than the cardinality.
+func (rb *Roaring) Select(i uint64) (uint32, bool) {
+	for k, c := range rb.containers {
+		if card := uint64(c.cardinality()); i >= card {
+			i -= card
+			continue
+		}
+		return uint32(rb.keys[k])<<16 | uint32(c.sel(int(i))), true
+	}
+	return 0, false
+}
+
+// Minimum returns the smallest value along with
+// true. It returns false when the bitmap is empty.
+func (rb *Roaring) Minimum() (uint32, bool) {
+	return rb.Select(0)
+}
+
+// Maximum returns the largest value along with
+// true. It returns false when the bitmap is empty.
+func (rb *Roaring) Maximum() (uint32, bool) {
+	if rb.IsEmpty() {
+		return 0, false
+	}
+	var (
+		last int       = len(rb.containers) - 1
+		c    container = rb.containers[last]
+	)
+	return uint32(rb.keys[last])<<16 | uint32(c.sel(c.cardinality()-1)), true
+}
+
+// - MARK: Iteration.
+
+// All returns an iterator over the values
+// in ascending order.
+func (rb *Roaring) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range rb.containers {
+			var (
+				hi uint32 = uint32(rb.keys[i]) << 16
+			)
+			if !c.each(func(x uint16) bool { return yield(hi | uint32(x)) }) {
+				return
+			}
+		}
+	}
+}
+
+// ToArray returns the values in ascending order.
+func (rb *Roaring) ToArray() []uint32 {
+	var (
+		values []uint32 = make([]uint32, 0, rb.Cardinality())
+	)
+	for v := range rb.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// - MARK: Set Algebra section.
+
+// Union returns a new bitmap holding the
+// values in `rb` or `other`.
+func (rb *Roaring) Union(other *Roaring) *Roaring {
+	return rb.merge(other, true, true,
+		func(ina, inb bool) bool { return ina || inb },
+		func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersect returns a new bitmap holding the
+// values in both `rb` and `other`.
+func (rb *Roaring) Intersect(other *Roaring) *Roaring {
+	return rb.merge(other, false, false,
+		func(ina, inb bool) bool { return ina && inb },
+		func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns a new bitmap holding the
+// values in `rb` but not in `other`.
+func (rb *Roaring) Difference(other *Roaring) *Roaring {
+	return rb.merge(other, true, false,
+		func(ina, inb bool) bool { return ina && !inb },
+		func(x, y uint64) uint64 { return x &^ y })
+}
+
+// SymmetricDifference returns a new bitmap holding
+// the values in either `rb` or `other` but not both.
+func (rb *Roaring) SymmetricDifference(other *Roaring) *Roaring {
+	return rb.merge(other, true, true,
+		func(ina, inb bool) bool { return ina != inb },
+		func(x, y uint64) uint64 { return x ^ y })
+}
+
+// merge walks the containers of `rb` and `other` by key.
+// Containers present on one side only are copied when
+// `keepa` ( resp. `keepb` ) is set; containers present
+// on both sides are combined with `keep` and `op`.
+func (rb *Roaring) merge(other *Roaring, keepa, keepb bool, keep func(ina, inb bool) bool, op func(x, y uint64) uint64) *Roaring {
+	var (
+		r    *Roaring = NewRoaring()
+		i, j int
+	)
+	push := func(key uint16, c container) {
+		if c != nil {
+			r.keys = append(r.keys, key)
+			r.containers = append(r.containers, c)
+		}
+	}
+	for i < len(rb.keys) || j < len(other.keys) {
+		switch {
+		case j >= len(other.keys) || (i < len(rb.keys) && rb.keys[i] < other.keys[j]):
+			if keepa {
+				push(rb.keys[i], rb.containers[i].clone())
+			}
+			i++
+		case i >= len(rb.keys) || other.keys[j] < rb.keys[i]:
+			if keepb {
+				push(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			push(rb.keys[i], combine(rb.containers[i], other.containers[j], keep, op))
+			i++
+			j++
+		}
+	}
+	return r
+}
@@ -0,0 +1,465 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// - MARK: Roaring-Container section.
+
+const (
+	// arrayMaxSize is the maximum cardinality
+	// of an array container.
+	arrayMaxSize = 4096
+	// bitmapWords is the number of words in
+	// a bitmap container ( 2^16 bits ).
+	bitmapWords = 1024
+)
+
+// container holds the low 16 bits of values sharing
+// the same high 16 bits. Mutating methods return the
+// resulting container which may be of another kind.
+type container interface {
+	add(x uint16) container
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	rank(x uint16) int
+	sel(i int) uint16
+	each(fn func(x uint16) bool) bool
+	bitmap() *bitmapContainer
+	clone() container
+}
+
+// arrayContainer is a sorted array of values,
+// used for sparse containers.
+type arrayContainer struct {
+	content []uint16
+}
+
+// bitmapContainer is a 2^16 bits bitmap,
+// used for dense containers.
+type bitmapContainer struct {
+	bits []uint64
+	card int
+}
+
+// interval16 is an inclusive run of values
+// [start, start+length].
+type interval16 struct {
+	start  uint16
+	length uint16
+}
+
+// runContainer is a sorted list of runs,
+// used for clustered containers.
+type runContainer struct {
+	runs []interval16
+}
+
+func newBitmapContainer() *bitmapContainer {
+	return &bitmapContainer{bits: make([]uint64, bitmapWords)}
+}
+
+// - MARK: array container.
+
+func (c *arrayContainer) search(x uint16) (int, bool) {
+	i := sort.Search(len(c.content), func(i int) bool { return c.content[i] >= x })
+	return i, i < len(c.content) && c.content[i] == x
+}
+
+func (c *arrayContainer) add(x uint16) container {
+	i, ok := c.search(x)
+	if ok {
+		return c
+	}
+	if len(c.content) >= arrayMaxSize {
+		return c.bitmap().add(x)
+	}
+	c.content = append(c.content, 0)
+	copy(c.content[i+1:], c.content[i:])
+	c.content[i] = x
+	return c
+}
+
+func (c *arrayContainer) remove(x uint16) container {
+	if i, ok := c.search(x); ok {
+		c.content = append(c.content[:i], c.content[i+1:]...)
+	}
+	return c
+}
+
+func (c *arrayContainer) contains(x uint16) bool {
+	_, ok := c.search(x)
+	return ok
+}
+
+func (c *arrayContainer) cardinality() int {
+	return len(c.content)
+}
+
+func (c *arrayContainer) rank(x uint16) int {
+	i, ok := c.search(x)
+	if ok {
+		i++
+	}
+	return i
+}
+
+func (c *arrayContainer) sel(i int) uint16 {
+	return c.content[i]
+}
+
+func (c *arrayContainer) each(fn func(x uint16) bool) bool {
+	for _, x := range c.content {
+		if !fn(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *arrayContainer) bitmap() *bitmapContainer {
+	var (
+		b *bitmapContainer = newBitmapContainer()
+	)
+	for _, x := range c.content {
+		b.bits[x>>6] |= 1 << (x & 63)
+	}
+	b.card = len(c.content)
+	return b
+}
+
+func (c *arrayContainer) clone() container {
+	return &arrayContainer{content: append([]uint16(nil), c.content...)}
+}
+
+// - MARK: bitmap container.
+
+func (c *bitmapContainer) add(x uint16) container {
+	if c.bits[x>>6]&(1<<(x&63)) == 0 {
+		c.bits[x>>6] |= 1 << (x & 63)
+		c.card++
+	}
+	return c
+}
+
+func (c *bitmapContainer) remove(x uint16) container {
+	if c.bits[x>>6]&(1<<(x&63)) != 0 {
+		c.bits[x>>6] &^= 1 << (x & 63)
+		c.card--
+	}
+	return c.normalize()
+}
+
+func (c *bitmapContainer) contains(x uint16) bool {
+	return c.bits[x>>6]&(1<<(x&63)) != 0
+}
+
+func (c *bitmapContainer) cardinality() int {
+	return c.card
+}
+
+func (c *bitmapContainer) rank(x uint16) int {
+	var (
+		n int
+	)
+	for i := 0; i < int(x>>6); i++ {
+		n += bits.OnesCount64(c.bits[i])
+	}
+	return n + bits.OnesCount64(c.bits[x>>6]<<(63-(x&63)))
+}
+
+func (c *bitmapContainer) sel(i int) uint16 {
+	var (
+		n int
+	)
+	for w, word := range c.bits {
+		if n = bits.OnesCount64(word); i >= n {
+			i -= n
+			continue
+		}
+		for ; i > 0; i-- {
+			word &= word - 1
+		}
+		return uint16(w<<6 + bits.TrailingZeros64(word))
+	}
+	panic(IndexError)
+}
+
+func (c *bitmapContainer) each(fn func(x uint16) bool) bool {
+	for w, word := range c.bits {
+		for ; word != 0; word &= word - 1 {
+			if !fn(uint16(w<<6 + bits.TrailingZeros64(word))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *bitmapContainer) bitmap() *bitmapContainer {
+	return c.clone().(*bitmapContainer)
+}
+
+func (c *bitmapContainer) clone() container {
+	return &bitmapContainer{bits: append([]uint64(nil), c.bits...), card: c.card}
+}
+
+// recount recomputes the cardinality.
+func (c *bitmapContainer) recount() {
+	c.card = 0
+	for _, word := range c.bits {
+		c.card += bits.OnesCount64(word)
+	}
+}
+
+// normalize converts the container to an array
+// container when it is sparse enough.
+func (c *bitmapContainer) normalize() container {
+	if c.card > arrayMaxSize {
+		return c
+	}
+	var (
+		a *arrayContainer = &arrayContainer{content: make([]uint16, 0, c.card)}
+	)
+	c.each(func(x uint16) bool {
+		a.content = append(a.content, x)
+		return true
+	})
+	return a
+}
+
+// - MARK: run container.
+
+func (c *runContainer) search(x uint16) (int, bool) {
+	// index of the last run starting at or before `x`.
+	i := sort.Search(len(c.runs), func(i int) bool { return c.runs[i].start > x }) - 1
+	return i, i >= 0 && uint32(x)-uint32(c.runs[i].start) <= uint32(c.runs[i].length)
+}
+
+func (c *runContainer) add(x uint16) container {
+	if c.contains(x) {
+		return c
+	}
+	return c.convert().add(x)
+}
+
+func (c *runContainer) remove(x uint16) container {
+	if !c.contains(x) {
+		return c
+	}
+	return c.convert().remove(x)
+}
+
+func (c *runContainer) contains(x uint16) bool {
+	_, ok := c.search(x)
+	return ok
+}
+
+func (c *runContainer) cardinality() int {
+	var (
+		n int
+	)
+	for _, r := range c.runs {
+		n += int(r.length) + 1
+	}
+	return n
+}
+
+func (c *runContainer) rank(x uint16) int {
+	var (
+		n int
+	)
+	for _, r := range c.runs {
+		if r.start > x {
+			break
+		}
+		if uint32(x) <= uint32(r.start)+uint32(r.length) {
+			return n + int(x-r.start) + 1
+		}
+		n += int(r.length) + 1
+	}
+	return n
+}
+
+func (c *runContainer) sel(i int) uint16 {
+	for _, r := range c.runs {
+		if i <= int(r.length) {
+			return r.start + uint16(i)
+		}
+		i -= int(r.length) + 1
+	}
+	panic(IndexError)
+}
+
+func (c *runContainer) each(fn func(x uint16) bool) bool {
+	for _, r := range c.runs {
+		for x := uint32(r.start); x <= uint32(r.start)+uint32(r.length); x++ {
+			if !fn(uint16(x)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *runContainer) bitmap() *bitmapContainer {
+	var (
+		b *bitmapContainer = newBitmapContainer()
+	)
+	c.each(func(x uint16) bool {
+		b.bits[x>>6] |= 1 << (x & 63)
+		return true
+	})
+	b.card = c.cardinality()
+	return b
+}
+
+func (c *runContainer) clone() container {
+	return &runContainer{runs: append([]interval16(nil), c.runs...)}
+}
+
+// convert returns the content as an array
+// or bitmap container.
+func (c *runContainer) convert() container {
+	return c.bitmap().normalize()
+}
+
+// - MARK: container utilities.
+
+// toRuns returns `c` as a run container.
+func toRuns(c container) *runContainer {
+	var (
+		r    *runContainer = &runContainer{}
+		last int           = -2
+	)
+	c.each(func(x uint16) bool {
+		if int(x) == last+1 {
+			r.runs[len(r.runs)-1].length++
+		} else {
+			r.runs = append(r.runs, interval16{start: x})
+		}
+		last = int(x)
+		return true
+	})
+	return r
+}
+
+// countRuns returns the number of runs in `c`.
+func countRuns(c container) int {
+	var (
+		n    int
+		last int = -2
+	)
+	c.each(func(x uint16) bool {
+		if int(x) != last+1 {
+			n++
+		}
+		last = int(x)
+		return true
+	})
+	return n
+}
+
+// optimize returns the smallest representation of
+// `c`, sizing containers as the reference
+// implementations do.
+func optimize(c container) container {
+	var (
+		card  int = c.cardinality()
+		nruns int = countRuns(c)
+		size  int = 8192
+	)
+	if card <= arrayMaxSize {
+		size = 2 + 2*card
+	}
+	if 2+4*nruns < size {
+		if _, ok := c.(*runContainer); ok {
+			return c
+		}
+		return toRuns(c)
+	}
+	if r, ok := c.(*runContainer); ok {
+		return r.convert()
+	}
+	return c
+}
+
+// combine applies a set operation to `a` and `b` where
+// `keep` decides membership for array containers and
+// `op` combines words for other containers. It returns
+// nil for an empty result.
+func combine(a, b container, keep func(ina, inb bool) bool, op func(x, y uint64) uint64) container {
+	var (
+		r container
+	)
+	aa, aok := a.(*arrayContainer)
+	bb, bok := b.(*arrayContainer)
+	if aok && bok {
+		var (
+			out  []uint16
+			i, j int
+		)
+		for i < len(aa.content) || j < len(bb.content) {
+			switch {
+			case j >= len(bb.content) || (i < len(aa.content) && aa.content[i] < bb.content[j]):
+				if keep(true, false) {
+					out = append(out, aa.content[i])
+				}
+				i++
+			case i >= len(aa.content) || bb.content[j] < aa.content[i]:
+				if keep(false, true) {
+					out = append(out, bb.content[j])
+				}
+				j++
+			default:
+				if keep(true, true) {
+					out = append(out, aa.content[i])
+				}
+				i++
+				j++
+			}
+		}
+		if len(out) > arrayMaxSize {
+			r = (&arrayContainer{content: out}).bitmap()
+		} else {
+			r = &arrayContainer{content: out}
+		}
+	} else {
+		var (
+			x, y *bitmapContainer = a.bitmap(), b.bitmap()
+		)
+		for i := range x.bits {
+			x.bits[i] = op(x.bits[i], y.bits[i])
+		}
+		x.recount()
+		r = x.normalize()
+	}
+	if r.cardinality() == 0 {
+		return nil
+	}
+	return r
+}
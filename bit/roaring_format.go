@@ -0,0 +1,338 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// - MARK: Roaring Serialization section.
+
+/*
+* implementation of the Roaring portable serialization
+* format ( https://github.com/RoaringBitmap/RoaringFormatSpec )
+* shared by the Java, C and Go implementations.
+ */
+
+const (
+	// roaringCOOKIE is the cookie of bitmaps
+	// holding run containers.
+	roaringCOOKIE uint32 = 12347
+	// roaringCOOKIENORUN is the cookie of bitmaps
+	// without run containers.
+	roaringCOOKIENORUN uint32 = 12346
+	// roaringNOOFFSET is the number of containers
+	// below which bitmaps holding run containers
+	// omit the offset header.
+	roaringNOOFFSET int = 4
+)
+
+// Error messages
+var (
+	RoaringFormatError error = errors.New("Roaring: invalid serialized format")
+)
+
+// hasRuns returns true when a run container is present.
+func (rb *Roaring) hasRuns() bool {
+	for _, c := range rb.containers {
+		if _, ok := c.(*runContainer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalBinary implements `encoding.BinaryMarshaler`
+// using the Roaring portable format.
+func (rb *Roaring) MarshalBinary() ([]byte, error) {
+	var (
+		buf    []byte
+		size   int  = len(rb.containers)
+		runs   bool = rb.hasRuns()
+		offset int
+	)
+	if runs {
+		buf = binary.LittleEndian.AppendUint32(buf, roaringCOOKIE|uint32(size-1)<<16)
+		var (
+			flags []byte = make([]byte, (size+7)/8)
+		)
+		for i, c := range rb.containers {
+			if _, ok := c.(*runContainer); ok {
+				flags[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf = append(buf, flags...)
+	} else {
+		buf = binary.LittleEndian.AppendUint32(buf, roaringCOOKIENORUN)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(size))
+	}
+	for i, key := range rb.keys {
+		buf = binary.LittleEndian.AppendUint16(buf, key)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(rb.containers[i].cardinality()-1))
+	}
+	if !runs || size >= roaringNOOFFSET {
+		offset = len(buf) + 4*size
+		for _, c := range rb.containers {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(offset))
+			offset += containerSize(c)
+		}
+	}
+	for _, c := range rb.containers {
+		switch c := c.(type) {
+		case *arrayContainer:
+			for _, x := range c.content {
+				buf = binary.LittleEndian.AppendUint16(buf, x)
+			}
+		case *bitmapContainer:
+			for _, w := range c.bits {
+				buf = binary.LittleEndian.AppendUint64(buf, w)
+			}
+		case *runContainer:
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(len(c.runs)))
+			for _, r := range c.runs {
+				buf = binary.LittleEndian.AppendUint16(buf, r.start)
+				buf = binary.LittleEndian.AppendUint16(buf, r.length)
+			}
+		}
+	}
+	return buf, nil
+}
+
+// containerSize returns the serialized size of `c`.
+func containerSize(c container) int {
+	switch c := c.(type) {
+	case *arrayContainer:
+		return 2 * len(c.content)
+	case *runContainer:
+		return 2 + 4*len(c.runs)
+	}
+	return 8 * bitmapWords
+}
+
+// UnmarshalBinary implements `encoding.BinaryUnmarshaler`
+// using the Roaring portable format.
+func (rb *Roaring) UnmarshalBinary(data []byte) error {
+	var (
+		r   *sliceReader = &sliceReader{data: data}
+		err error
+	)
+	if err = rb.decode(r); err != nil {
+		return err
+	}
+	if len(r.data) != 0 {
+		return RoaringFormatError
+	}
+	return nil
+}
+
+// WriteTo implements `io.WriterTo` using the
+// Roaring portable format.
+func (rb *Roaring) WriteTo(w io.Writer) (int64, error) {
+	data, err := rb.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom implements `io.ReaderFrom` using the
+// Roaring portable format. It reads exactly one
+// serialized bitmap from `r`.
+func (rb *Roaring) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		sr *streamReader = &streamReader{r: r}
+	)
+	err := rb.decode(sr)
+	return sr.n, err
+}
+
+// byteReader is the source of `decode`.
+type byteReader interface {
+	next(n int) ([]byte, error)
+}
+
+// sliceReader reads from a byte slice.
+type sliceReader struct {
+	data []byte
+}
+
+func (r *sliceReader) next(n int) ([]byte, error) {
+	if n > len(r.data) {
+		return nil, RoaringFormatError
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+// streamReader reads from an `io.Reader` and
+// counts the consumed bytes.
+type streamReader struct {
+	r   io.Reader
+	n   int64
+	buf []byte
+}
+
+func (r *streamReader) next(n int) ([]byte, error) {
+	if cap(r.buf) < n {
+		r.buf = make([]byte, n)
+	}
+	m, err := io.ReadFull(r.r, r.buf[:n])
+	r.n += int64(m)
+	if err == io.EOF && r.n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return r.buf[:n], err
+}
+
+// decode reads a serialized bitmap from `r` and
+// replaces the content of `rb` on success.
+func (rb *Roaring) decode(r byteReader) error {
+	var (
+		b     []byte
+		err   error
+		size  int
+		flags []byte
+		cards []int
+		keys  []uint16
+		conts []container
+	)
+	if b, err = r.next(4); err != nil {
+		return err
+	}
+	cookie := binary.LittleEndian.Uint32(b)
+	switch {
+	case cookie&0xFFFF == roaringCOOKIE:
+		size = int(cookie>>16) + 1
+		if b, err = r.next((size + 7) / 8); err != nil {
+			return err
+		}
+		flags = append([]byte(nil), b...)
+	case cookie == roaringCOOKIENORUN:
+		if b, err = r.next(4); err != nil {
+			return err
+		}
+		size = int(binary.LittleEndian.Uint32(b))
+		if size > 1<<16 {
+			return RoaringFormatError
+		}
+	default:
+		return RoaringFormatError
+	}
+	if b, err = r.next(4 * size); err != nil {
+		return err
+	}
+	keys, cards = make([]uint16, size), make([]int, size)
+	for i := range size {
+		keys[i] = binary.LittleEndian.Uint16(b[4*i:])
+		cards[i] = int(binary.LittleEndian.Uint16(b[4*i+2:])) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return RoaringFormatError
+		}
+	}
+	if flags == nil || size >= roaringNOOFFSET {
+		// offsets are redundant for sequential reads.
+		if _, err = r.next(4 * size); err != nil {
+			return err
+		}
+	}
+	conts = make([]container, size)
+	for i := range size {
+		switch {
+		case flags != nil && flags[i/8]&(1<<(i%8)) != 0:
+			conts[i], err = decodeRuns(r)
+		case cards[i] <= arrayMaxSize:
+			conts[i], err = decodeArray(r, cards[i])
+		default:
+			conts[i], err = decodeBitmap(r)
+		}
+		if err != nil {
+			return err
+		}
+		if conts[i].cardinality() != cards[i] {
+			return RoaringFormatError
+		}
+	}
+	rb.keys, rb.containers = keys, conts
+	return nil
+}
+
+func decodeArray(r byteReader, card int) (container, error) {
+	b, err := r.next(2 * card)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		c *arrayContainer = &arrayContainer{content: make([]uint16, card)}
+	)
+	for i := range c.content {
+		c.content[i] = binary.LittleEndian.Uint16(b[2*i:])
+		if i > 0 && c.content[i] <= c.content[i-1] {
+			return nil, RoaringFormatError
+		}
+	}
+	return c, nil
+}
+
+func decodeBitmap(r byteReader) (container, error) {
+	b, err := r.next(8 * bitmapWords)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		c *bitmapContainer = newBitmapContainer()
+	)
+	for i := range c.bits {
+		c.bits[i] = binary.LittleEndian.Uint64(b[8*i:])
+		c.card += bits.OnesCount64(c.bits[i])
+	}
+	return c, nil
+}
+
+func decodeRuns(r byteReader) (container, error) {
+	b, err := r.next(2)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		n    int           = int(binary.LittleEndian.Uint16(b))
+		c    *runContainer = &runContainer{runs: make([]interval16, n)}
+		last int           = -2
+	)
+	if b, err = r.next(4 * n); err != nil {
+		return nil, err
+	}
+	for i := range c.runs {
+		c.runs[i].start = binary.LittleEndian.Uint16(b[4*i:])
+		c.runs[i].length = binary.LittleEndian.Uint16(b[4*i+2:])
+		if int(c.runs[i].start) <= last || int(c.runs[i].start)+int(c.runs[i].length) > 0xFFFF {
+			return nil, RoaringFormatError
+		}
+		last = int(c.runs[i].start) + int(c.runs[i].length)
+	}
+	return c, nil
+}
@@ -0,0 +1,301 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// reference returns the sorted values of `set`.
+func reference(set map[uint32]bool) []uint32 {
+	var (
+		values []uint32
+	)
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+// randomRoaring returns a bitmap mixing sparse, dense
+// and clustered containers along with its reference set.
+func randomRoaring(rng *rand.Rand) (*Roaring, map[uint32]bool) {
+	var (
+		rb  *Roaring        = NewRoaring()
+		set map[uint32]bool = make(map[uint32]bool)
+	)
+	add := func(v uint32) {
+		rb.Add(v)
+		set[v] = true
+	}
+	for i := 0; i < 500; i++ {
+		add(uint32(rng.Intn(1 << 20)))
+	}
+	for i := 0; i < 6000; i++ {
+		add(3<<16 | uint32(rng.Intn(1<<16)))
+	}
+	for v := uint32(5 << 16); v < 5<<16+3000; v++ {
+		add(v)
+	}
+	return rb, set
+}
+
+func TestRoaringBasic(t *testing.T) {
+	var (
+		rb  *Roaring = NewRoaring()
+		rng          = rand.New(rand.NewSource(1))
+		set          = make(map[uint32]bool)
+	)
+	for i := 0; i < 20000; i++ {
+		v := uint32(rng.Intn(1 << 18))
+		if rng.Intn(3) == 0 {
+			rb.Remove(v)
+			delete(set, v)
+		} else {
+			rb.Add(v)
+			set[v] = true
+		}
+	}
+	if rb.Cardinality() != uint64(len(set)) {
+		t.Fatalf("expected Cardinality()==%d; got %d.", len(set), rb.Cardinality())
+	}
+	for v := uint32(0); v < 1<<18; v++ {
+		if rb.Contains(v) != set[v] {
+			t.Fatalf("inconsistent state for %d.", v)
+		}
+	}
+	if !slices.Equal(rb.ToArray(), reference(set)) {
+		t.Fatal("assertion failed, expected ascending values.")
+	}
+	for v := range set {
+		rb.Remove(v)
+	}
+	if !rb.IsEmpty() || rb.Cardinality() != 0 {
+		t.Fatal("assertion failed, expected empty bitmap.")
+	}
+}
+
+func TestRoaringContainers(t *testing.T) {
+	var (
+		rb *Roaring = NewRoaring()
+	)
+	for v := uint32(0); v <= arrayMaxSize; v++ {
+		rb.Add(v * 2)
+	}
+	if _, ok := rb.containers[0].(*bitmapContainer); !ok {
+		t.Fatal("assertion failed, expected bitmap container.")
+	}
+	rb.Remove(0)
+	if _, ok := rb.containers[0].(*arrayContainer); !ok {
+		t.Fatal("assertion failed, expected array container.")
+	}
+	rb.Clear()
+	for v := uint32(100); v < 20000; v++ {
+		rb.Add(v)
+	}
+	rb.RunOptimize()
+	if c, ok := rb.containers[0].(*runContainer); !ok || len(c.runs) != 1 {
+		t.Fatal("assertion failed, expected single run container.")
+	}
+	if !rb.Contains(100) || !rb.Contains(19999) || rb.Contains(99) || rb.Contains(20000) {
+		t.Fatal("inconsistent state.")
+	}
+	if rb.Rank(150) != 51 || rb.Rank(50) != 0 || rb.Rank(1<<20) != 19900 {
+		t.Fatalf("unexpected rank %d, %d, %d.", rb.Rank(150), rb.Rank(50), rb.Rank(1<<20))
+	}
+	if v, ok := rb.Select(50); !ok || v != 150 {
+		t.Fatalf("expected Select(50)==150; got %d, %t.", v, ok)
+	}
+	rb.Remove(500)
+	if _, ok := rb.containers[0].(*runContainer); ok || rb.Contains(500) || rb.Cardinality() != 19899 {
+		t.Fatal("assertion failed, expected converted container.")
+	}
+	rb.Add(500)
+	rb.RunOptimize()
+	rb.Add(5)
+	if !rb.Contains(5) || rb.Cardinality() != 19901 {
+		t.Fatal("inconsistent state.")
+	}
+}
+
+func TestRoaringRankSelect(t *testing.T) {
+	var (
+		rng *rand.Rand = rand.New(rand.NewSource(2))
+	)
+	rb, set := randomRoaring(rng)
+	values := reference(set)
+	for _, optimized := range []bool{false, true} {
+		if optimized {
+			rb.RunOptimize()
+		}
+		for i, v := range values {
+			if r := rb.Rank(v); r != uint64(i+1) {
+				t.Fatalf("expected Rank(%d)==%d; got %d.", v, i+1, r)
+			}
+			if s, ok := rb.Select(uint64(i)); !ok || s != v {
+				t.Fatalf("expected Select(%d)==%d; got %d.", i, v, s)
+			}
+		}
+		if _, ok := rb.Select(uint64(len(values))); ok {
+			t.Fatal("assertion failed, expected out of range Select.")
+		}
+		if lo, ok := rb.Minimum(); !ok || lo != values[0] {
+			t.Fatalf("expected Minimum()==%d; got %d.", values[0], lo)
+		}
+		if hi, ok := rb.Maximum(); !ok || hi != values[len(values)-1] {
+			t.Fatalf("expected Maximum()==%d; got %d.", values[len(values)-1], hi)
+		}
+	}
+}
+
+func TestRoaringAlgebra(t *testing.T) {
+	var (
+		rng *rand.Rand = rand.New(rand.NewSource(3))
+	)
+	a, sa := randomRoaring(rng)
+	b, sb := randomRoaring(rng)
+	b.RunOptimize()
+	var (
+		union, inter, diff, xor = make(map[uint32]bool), make(map[uint32]bool), make(map[uint32]bool), make(map[uint32]bool)
+	)
+	for v := range sa {
+		union[v] = true
+		if sb[v] {
+			inter[v] = true
+		} else {
+			diff[v] = true
+			xor[v] = true
+		}
+	}
+	for v := range sb {
+		union[v] = true
+		if !sa[v] {
+			xor[v] = true
+		}
+	}
+	for _, c := range []struct {
+		name string
+		got  *Roaring
+		want map[uint32]bool
+	}{
+		{"Union", a.Union(b), union},
+		{"Intersect", a.Intersect(b), inter},
+		{"Difference", a.Difference(b), diff},
+		{"SymmetricDifference", a.SymmetricDifference(b), xor},
+	} {
+		if !slices.Equal(c.got.ToArray(), reference(c.want)) {
+			t.Errorf("assertion failed, unexpected %s.", c.name)
+		}
+		for _, cont := range c.got.containers {
+			if cont.cardinality() == 0 {
+				t.Errorf("assertion failed, empty container after %s.", c.name)
+			}
+		}
+	}
+	if !a.Union(a).Equal(a) || !a.Difference(a).IsEmpty() || a.Equal(b) {
+		t.Fatal("inconsistent state.")
+	}
+	c := a.Clone()
+	c.Add(1 << 31)
+	if a.Contains(1<<31) || a.Equal(c) {
+		t.Fatal("assertion failed, expected deep copy.")
+	}
+}
+
+func TestRoaringFormat(t *testing.T) {
+	var (
+		rb *Roaring = RoaringOf(1, 2, 3)
+	)
+	data, _ := rb.MarshalBinary()
+	want := []byte{
+		0x3A, 0x30, 0x00, 0x00, // cookie
+		0x01, 0x00, 0x00, 0x00, // size
+		0x00, 0x00, 0x02, 0x00, // key, cardinality-1
+		0x10, 0x00, 0x00, 0x00, // offset
+		0x01, 0x00, 0x02, 0x00, 0x03, 0x00,
+	}
+	if !bytes.Equal(data, want) {
+		t.Fatalf("expected %x; got %x.", want, data)
+	}
+	rb.RunOptimize()
+	data, _ = rb.MarshalBinary()
+	want = []byte{
+		0x3B, 0x30, 0x00, 0x00, // cookie, size-1
+		0x01,                   // run flags
+		0x00, 0x00, 0x02, 0x00, // key, cardinality-1
+		0x01, 0x00, 0x01, 0x00, 0x02, 0x00, // runs
+	}
+	if !bytes.Equal(data, want) {
+		t.Fatalf("expected %x; got %x.", want, data)
+	}
+	var (
+		r *Roaring = NewRoaring()
+	)
+	if err := r.UnmarshalBinary(data); err != nil || !r.Equal(rb) {
+		t.Fatalf("assertion failed, unexpected decoding ( %v ).", err)
+	}
+	data, _ = NewRoaring().MarshalBinary()
+	if err := r.UnmarshalBinary(data); err != nil || !r.IsEmpty() {
+		t.Fatalf("assertion failed, expected empty bitmap ( %v ).", err)
+	}
+}
+
+func TestRoaringSerialize(t *testing.T) {
+	var (
+		rng *rand.Rand = rand.New(rand.NewSource(4))
+		buf bytes.Buffer
+	)
+	rb, _ := randomRoaring(rng)
+	for _, optimized := range []bool{false, true} {
+		if optimized {
+			rb.RunOptimize()
+		}
+		buf.Reset()
+		n, err := rb.WriteTo(&buf)
+		if err != nil || n != int64(buf.Len()) {
+			t.Fatalf("assertion failed, unexpected WriteTo ( %d, %v ).", n, err)
+		}
+		buf.WriteString("trailer")
+		var (
+			r *Roaring = NewRoaring()
+		)
+		m, err := r.ReadFrom(&buf)
+		if err != nil || m != n || buf.String() != "trailer" {
+			t.Fatalf("assertion failed, unexpected ReadFrom ( %d, %v ).", m, err)
+		}
+		if !r.Equal(rb) {
+			t.Fatal("inconsistent state.")
+		}
+		data, _ := rb.MarshalBinary()
+		for _, bad := range [][]byte{nil, data[:len(data)-1], append(data, 0), {0x00, 0x00, 0x00, 0x00}} {
+			if err := r.UnmarshalBinary(bad); err != RoaringFormatError {
+				t.Fatalf("expected RoaringFormatError; got %v.", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import "math/bits"
+
+// - MARK: Segment-Tree section.
+
+// Monoid is an associative operation `Combine`
+// with identity element `Identity`.
+type Monoid[T any] struct {
+	Identity T
+	Combine  func(a, b T) T
+}
+
+// Action describes range updates `U` applied lazily
+// to aggregated values `T`. `Apply` applies update
+// `u` to the aggregate `v` of `n` consecutive values;
+// `Compose` returns the update equivalent to applying
+// `older` and then `newer`; `Identity` is the update
+// leaving values unchanged.
+type Action[T, U any] struct {
+	Identity U
+	Apply    func(u U, v T, n int) T
+	Compose  func(newer, older U) U
+}
+
+// SegmentTree is a segment tree with lazy propagation
+// answering range queries over a `Monoid` and range
+// updates described by an `Action` in O(log n). Indices
+// are 0-based and ranges are half-open; out of range
+// indices panic with `IndexError`.
+type SegmentTree[T, U any] struct {
+	n, size, log int
+	data         []T // 1-based heap of 2*size nodes
+	lazy         []U // pending updates of internal nodes
+	m            Monoid[T]
+	a            Action[T, U]
+}
+
+// NewSegmentTree allocates and initializes a new
+// `SegmentTree` holding `values` and returns a
+// pointer to it.
+func NewSegmentTree[T, U any](values []T, m Monoid[T], a Action[T, U]) *SegmentTree[T, U] {
+	var (
+		size uint64             = max(RoundNextP2(uint64(len(values))), 1)
+		st   *SegmentTree[T, U] = &SegmentTree[T, U]{
+			n:    len(values),
+			size: int(size),
+			log:  Log2Floor(size),
+			m:    m,
+			a:    a,
+		}
+	)
+	st.data = make([]T, 2*st.size)
+	st.lazy = make([]U, st.size)
+	for i := range st.data {
+		st.data[i] = m.Identity
+	}
+	for i := range st.lazy {
+		st.lazy[i] = a.Identity
+	}
+	copy(st.data[st.size:], values)
+	for k := st.size - 1; k > 0; k-- {
+		st.update(k)
+	}
+	return st
+}
+
+// Len returns the number of values.
+func (st *SegmentTree[T, U]) Len() int {
+	return st.n
+}
+
+// width returns the number of leaves below node `k`.
+func (st *SegmentTree[T, U]) width(k int) int {
+	return st.size >> (bits.Len(uint(k)) - 1)
+}
+
+func (st *SegmentTree[T, U]) update(k int) {
+	st.data[k] = st.m.Combine(st.data[2*k], st.data[2*k+1])
+}
+
+func (st *SegmentTree[T, U]) apply(k int, u U) {
+	st.data[k] = st.a.Apply(u, st.data[k], st.width(k))
+	if k < st.size {
+		st.lazy[k] = st.a.Compose(u, st.lazy[k])
+	}
+}
+
+func (st *SegmentTree[T, U]) push(k int) {
+	st.apply(2*k, st.lazy[k])
+	st.apply(2*k+1, st.lazy[k])
+	st.lazy[k] = st.a.Identity
+}
+
+// pushAll propagates pending updates down to
+// the boundaries of [lo, hi) ( leaf indices ).
+func (st *SegmentTree[T, U]) pushAll(lo, hi int) {
+	for i := st.log; i >= 1; i-- {
+		if (lo>>i)<<i != lo {
+			st.push(lo >> i)
+		}
+		if (hi>>i)<<i != hi {
+			st.push((hi - 1) >> i)
+		}
+	}
+}
+
+func (st *SegmentTree[T, U]) check(lo, hi int) {
+	if lo < 0 || lo > hi || hi > st.n {
+		panic(IndexError)
+	}
+}
+
+// Get returns value `i`.
+func (st *SegmentTree[T, U]) Get(i int) T {
+	st.check(i, i+1)
+	i += st.size
+	for k := st.log; k >= 1; k-- {
+		st.push(i >> k)
+	}
+	return st.data[i]
+}
+
+// Set sets value `i` to `v`.
+func (st *SegmentTree[T, U]) Set(i int, v T) {
+	st.check(i, i+1)
+	i += st.size
+	for k := st.log; k >= 1; k-- {
+		st.push(i >> k)
+	}
+	st.data[i] = v
+	for k := 1; k <= st.log; k++ {
+		st.update(i >> k)
+	}
+}
+
+// Query returns the combination of values in [lo, hi).
+func (st *SegmentTree[T, U]) Query(lo, hi int) T {
+	st.check(lo, hi)
+	if lo == hi {
+		return st.m.Identity
+	}
+	lo, hi = lo+st.size, hi+st.size
+	st.pushAll(lo, hi)
+	var (
+		left, right T = st.m.Identity, st.m.Identity
+	)
+	for ; lo < hi; lo, hi = lo>>1, hi>>1 {
+		if lo&1 == 1 {
+			left = st.m.Combine(left, st.data[lo])
+			lo++
+		}
+		if hi&1 == 1 {
+			hi--
+			right = st.m.Combine(st.data[hi], right)
+		}
+	}
+	return st.m.Combine(left, right)
+}
+
+// All returns the combination of all values.
+func (st *SegmentTree[T, U]) All() T {
+	return st.data[1]
+}
+
+// Update applies `u` to every value in [lo, hi).
+func (st *SegmentTree[T, U]) Update(lo, hi int, u U) {
+	st.check(lo, hi)
+	if lo == hi {
+		return
+	}
+	lo, hi = lo+st.size, hi+st.size
+	st.pushAll(lo, hi)
+	for l, r := lo, hi; l < r; l, r = l>>1, r>>1 {
+		if l&1 == 1 {
+			st.apply(l, u)
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			st.apply(r, u)
+		}
+	}
+	for i := 1; i <= st.log; i++ {
+		if (lo>>i)<<i != lo {
+			st.update(lo >> i)
+		}
+		if (hi>>i)<<i != hi {
+			st.update((hi - 1) >> i)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// sumAdd is a range-add / range-sum segment tree.
+func sumAdd(values []int) *SegmentTree[int, int] {
+	return NewSegmentTree(values,
+		Monoid[int]{Identity: 0, Combine: func(a, b int) int { return a + b }},
+		Action[int, int]{
+			Identity: 0,
+			Apply:    func(u, v, n int) int { return v + u*n },
+			Compose:  func(newer, older int) int { return newer + older },
+		})
+}
+
+// assignment is a pending assignment update.
+type assignment struct {
+	v  int
+	ok bool
+}
+
+// minAssign is a range-assign / range-min segment tree.
+func minAssign(values []int) *SegmentTree[int, assignment] {
+	return NewSegmentTree(values,
+		Monoid[int]{Identity: math.MaxInt, Combine: func(a, b int) int { return min(a, b) }},
+		Action[int, assignment]{
+			Identity: assignment{},
+			Apply: func(u assignment, v, n int) int {
+				if u.ok {
+					return u.v
+				}
+				return v
+			},
+			Compose: func(newer, older assignment) assignment {
+				if newer.ok {
+					return newer
+				}
+				return older
+			},
+		})
+}
+
+func TestSegmentTree(t *testing.T) {
+	var (
+		rng    *rand.Rand = rand.New(rand.NewSource(3))
+		values []int      = make([]int, 777)
+	)
+	for i := range values {
+		values[i] = rng.Intn(1000)
+	}
+	sums, mins := sumAdd(values), minAssign(values)
+	for step := 0; step < 3000; step++ {
+		lo := rng.Intn(len(values) + 1)
+		hi := lo + rng.Intn(len(values)+1-lo)
+		switch rng.Intn(4) {
+		case 0:
+			d := rng.Intn(50) - 25
+			for i := lo; i < hi; i++ {
+				values[i] += d
+			}
+			sums.Update(lo, hi, d)
+			for i := lo; i < hi; i++ {
+				mins.Set(i, values[i])
+			}
+		case 1:
+			v := rng.Intn(1000)
+			for i := lo; i < hi; i++ {
+				values[i] = v
+			}
+			mins.Update(lo, hi, assignment{v: v, ok: true})
+			for i := lo; i < hi; i++ {
+				sums.Set(i, v)
+			}
+		default:
+			var (
+				sum int
+				low int = math.MaxInt
+			)
+			for _, v := range values[lo:hi] {
+				sum, low = sum+v, min(low, v)
+			}
+			if got := sums.Query(lo, hi); got != sum {
+				t.Fatalf("expected sum Query(%d, %d)==%d; got %d.", lo, hi, sum, got)
+			}
+			if got := mins.Query(lo, hi); got != low {
+				t.Fatalf("expected min Query(%d, %d)==%d; got %d.", lo, hi, low, got)
+			}
+		}
+	}
+	var (
+		total int
+	)
+	for i, v := range values {
+		if sums.Get(i) != v || mins.Get(i) != v {
+			t.Fatalf("inconsistent state at %d.", i)
+		}
+		total += v
+	}
+	if sums.All() != total || sums.Len() != len(values) {
+		t.Fatal("inconsistent state.")
+	}
+	if empty := sumAdd(nil); empty.Len() != 0 || empty.Query(0, 0) != 0 || empty.All() != 0 {
+		t.Fatal("assertion failed, expected empty tree.")
+	}
+	defer func() {
+		if recover() != IndexError {
+			t.Fatal("assertion failed, expected IndexError.")
+		}
+	}()
+	sums.Query(1, len(values)+1)
+}
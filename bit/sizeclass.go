@@ -0,0 +1,75 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+// - MARK: Size-Class section.
+
+// SizeClass is a power of two size class taken from
+// the block table, ranging from 8 bytes ( class 0 )
+// to 4 GiB.
+type SizeClass int
+
+// sizeclasses are the distinct block sizes.
+var (
+	sizeclasses blktable = blocks[2:]
+)
+
+// NumSizeClasses returns the number of size classes.
+func NumSizeClasses() int {
+	return len(sizeclasses)
+}
+
+// ClassOf returns the smallest class holding `size`
+// bytes along with true. It returns false when `size`
+// exceeds the largest class.
+func ClassOf(size int) (SizeClass, bool) {
+	var (
+		c int = max(Log2Ceil(uint64(max(size, 0)))-Log2Floor(uint64(sizeclasses[0])), 0)
+	)
+	if c >= len(sizeclasses) {
+		return -1, false
+	}
+	return SizeClass(c), true
+}
+
+// Size returns the size of class `c` in bytes.
+// It panics when `c` is out of range.
+func (c SizeClass) Size() int {
+	return sizeclasses[c]
+}
+
+// Valid returns whether `c` is a valid class.
+func (c SizeClass) Valid() bool {
+	return c >= 0 && int(c) < len(sizeclasses)
+}
+
+// WasteRatio returns the fraction of the class size
+// left unused when storing `size` bytes, or 0 when
+// `size` exceeds the largest class.
+func WasteRatio(size int) float64 {
+	c, ok := ClassOf(size)
+	if !ok {
+		return 0
+	}
+	return float64(c.Size()-max(size, 0)) / float64(c.Size())
+}
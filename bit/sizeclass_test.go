@@ -0,0 +1,49 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package bit
+
+import "testing"
+
+func TestSizeClass(t *testing.T) {
+	if NumSizeClasses() != 30 {
+		t.Fatalf("expected NumSizeClasses()==30; got %d.", NumSizeClasses())
+	}
+	for _, c := range []struct{ size, class, bytes int }{{-1, 0, 8}, {0, 0, 8}, {1, 0, 8}, {8, 0, 8}, {9, 1, 16}, {1500, 8, 2048}, {1 << 32, 29, 1 << 32}} {
+		class, ok := ClassOf(c.size)
+		if !ok || int(class) != c.class || class.Size() != c.bytes || !class.Valid() {
+			t.Errorf("expected ClassOf(%d)==%d ( %d bytes ); got %d, %t.", c.size, c.class, c.bytes, class, ok)
+		}
+	}
+	if c, ok := ClassOf(1<<32 + 1); ok || c.Valid() {
+		t.Fatal("assertion failed, expected oversized class.")
+	}
+	for size := 1; size < 1<<20; size = size*3/2 + 1 {
+		class, _ := ClassOf(size)
+		if class.Size() < size || (class > 0 && SizeClass(class-1).Size() >= size) {
+			t.Fatalf("assertion failed, class %d is not the smallest for %d.", class, size)
+		}
+	}
+	if WasteRatio(1024) != 0 || WasteRatio(1025) != float64(1023)/2048 || WasteRatio(1<<33) != 0 {
+		t.Fatal("assertion failed, invalid WasteRatio.")
+	}
+}
@@ -0,0 +1,96 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package pointers
+
+import (
+	"sync/atomic"
+)
+
+// - MARK: Atomic-Array section.
+
+// AtomicArray is a fixed size array of atomic
+// pointers to `T`. It is the type-safe alternative
+// to slot functions operating on raw addresses. All
+// methods panic with `EPTRRANGE` when the index is
+// out of range.
+type AtomicArray[T any] struct {
+	slots []atomic.Pointer[T]
+}
+
+// NewAtomicArray allocates and initializes a new
+// `AtomicArray` with `n` nil slots and returns a
+// pointer to it.
+func NewAtomicArray[T any](n int) *AtomicArray[T] {
+	return &AtomicArray[T]{slots: make([]atomic.Pointer[T], n)}
+}
+
+// Len returns the number of slots.
+func (a *AtomicArray[T]) Len() int {
+	return len(a.slots)
+}
+
+// Load atomically loads `i` slot.
+func (a *AtomicArray[T]) Load(i int) *T {
+	return a.slot(i).Load()
+}
+
+// Store atomically stores `v` in `i` slot.
+func (a *AtomicArray[T]) Store(i int, v *T) {
+	a.slot(i).Store(v)
+}
+
+// CompareAndSwap performs CAS operation on `i`
+// slot and swaps `old` to `new`. It returns true
+// when succesfull.
+func (a *AtomicArray[T]) CompareAndSwap(i int, old, new *T) bool {
+	return a.slot(i).CompareAndSwap(old, new)
+}
+
+// Swap atomically stores `v` in `i` slot and
+// returns the previous value.
+func (a *AtomicArray[T]) Swap(i int, v *T) *T {
+	return a.slot(i).Swap(v)
+}
+
+// SetIfNil writes `v` to `i` slot iff its nil. It
+// returns true when succesfull.
+func (a *AtomicArray[T]) SetIfNil(i int, v *T) bool {
+	return a.slot(i).CompareAndSwap(nil, v)
+}
+
+// Pop empties `i` slot and returns its previous
+// value along with true iff it was not nil.
+func (a *AtomicArray[T]) Pop(i int) (*T, bool) {
+	var (
+		v *T = a.slot(i).Swap(nil)
+	)
+	return v, v != nil
+}
+
+// slot returns `i` slot after bounds checking.
+func (a *AtomicArray[T]) slot(i int) *atomic.Pointer[T] {
+	if i < 0 || i >= len(a.slots) {
+		panic(EPTRRANGE)
+	}
+	return &a.slots[i]
+}
@@ -0,0 +1,127 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package pointers
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicArrayGeneric(t *testing.T) {
+	var (
+		a    *AtomicArray[Sample] = NewAtomicArray[Sample](8)
+		s, n *Sample              = &Sample{8}, &Sample{16}
+		v    *Sample
+		ok   bool
+	)
+	if a.Len() != 8 {
+		t.Fatal("assertion failed, expected Len()==8. got", a.Len())
+	}
+	if !a.SetIfNil(6, s) {
+		t.Fatal("inconsistent state, cannot set slot.")
+	}
+	if a.SetIfNil(6, n) {
+		t.Fatal("inconsistent state, expected occupied slot.")
+	}
+	if a.Load(6) != s {
+		t.Fatal("assertion failed, expected s.")
+	}
+	if a.CompareAndSwap(6, n, s) || !a.CompareAndSwap(6, s, n) {
+		t.Fatal("inconsistent state, unexpected CAS result.")
+	}
+	if v = a.Swap(6, s); v != n {
+		t.Fatal("assertion failed, expected n.")
+	}
+	if v, ok = a.Pop(6); !ok || v != s || v.value != 8 {
+		t.Fatal("inconsistent state, cannot pop slot.")
+	}
+	if v, ok = a.Pop(6); ok || v != nil {
+		t.Fatal("assertion failed, expected empty slot.")
+	}
+	a.Store(0, n)
+	if a.Load(0).value != 16 {
+		t.Fatal("assertion failed, expected 16.")
+	}
+}
+
+func TestAtomicArrayRange(t *testing.T) {
+	var (
+		a *AtomicArray[Sample] = NewAtomicArray[Sample](4)
+	)
+	for _, i := range []int{-1, 4} {
+		func() {
+			defer func() {
+				if r := recover(); r != EPTRRANGE {
+					t.Fatal("assertion failed, expected panic with EPTRRANGE. got", r)
+				}
+			}()
+			a.Load(i)
+		}()
+	}
+}
+
+func TestAtomicArrayConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nrounds  = 1000
+		nslots   = 4
+	)
+	var (
+		a      *AtomicArray[Sample] = NewAtomicArray[Sample](nslots)
+		set    [nworkers]int
+		popped [nworkers]int
+		wg     sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for n := 0; n < nrounds; n++ {
+				i := n % nslots
+				if a.SetIfNil(i, &Sample{w}) {
+					set[w]++
+					continue
+				}
+				if _, ok := a.Pop(i); ok {
+					popped[w]++
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	var (
+		nset, npop, left int
+	)
+	for w := range set {
+		nset += set[w]
+		npop += popped[w]
+	}
+	for i := 0; i < nslots; i++ {
+		if a.Load(i) != nil {
+			left++
+		}
+	}
+	if nset != npop+left {
+		t.Fatalf("inconsistent state, set(%d) != popped(%d) + left(%d).", nset, npop, left)
+	}
+}
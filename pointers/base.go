@@ -38,12 +38,14 @@ const (
 	ArchADDRSIZE = 32 << uintptr(^uintptr(0)>>63)
 	ArchWORDSIZE = ArchADDRSIZE >> 3
 	ArchMAXTAG   = ArchWORDSIZE - 1
-	ArchPTRMASK  = ^uintptr((ArchADDRSIZE >> 5) + 1)
+	ArchPTRMASK  = ^uintptr(ArchMAXTAG)
 )
 
 var (
 	EPTRINVAL  error = errors.New("pointer: invalid.")
 	EPTRINVALT error = errors.New("pointer: invalid tag.")
+	ECASNADDR  error = errors.New("pointer: duplicate CASN address.")
+	EPTRRANGE  error = errors.New("pointer: index out of range.")
 )
 
 var (
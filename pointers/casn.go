@@ -0,0 +1,170 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package pointers
+
+import (
+	"sort"
+	"sync/atomic"
+	"unsafe"
+)
+
+/*
+* implementation of Harris, Fraser and Pratt CASN
+* built on top of RDCSS.
+ */
+
+// - MARK: CASN section.
+
+// CASN status words. A `CASNDescriptor` starts in
+// undecided state and moves exactly once to either
+// succeeded or failed state.
+var (
+	casnUNDECIDED unsafe.Pointer = unsafe.Pointer(new(uint64))
+	casnSUCCEEDED unsafe.Pointer = unsafe.Pointer(new(uint64))
+	casnFAILED    unsafe.Pointer = unsafe.Pointer(new(uint64))
+)
+
+// CASNEntry describes a single word update of a CASN
+// operation. `Addr` is swapped from `Old` to `New`.
+// Neither `Old` nor `New` may carry a tag as low-order
+// bits are reserved for descriptors.
+type CASNEntry struct {
+	Addr *unsafe.Pointer // data address
+	Old  unsafe.Pointer  // expected value
+	New  unsafe.Pointer  // new value
+}
+
+// CASNDescriptor is descriptor for CASN. It holds the
+// status word and entries sorted by address. A descriptor
+// is active when referenced from any of its addresses.
+// Pointer tagging ( `0x2` ) is used to distinct
+// `CASNDescriptor` pointers from `RDCSSDescriptor` ones.
+type CASNDescriptor struct {
+	status  unsafe.Pointer // status word
+	entries []CASNEntry    // sorted entries
+}
+
+// CASN performs a N-Word Compare-and-Swap atomic
+// operation. It atomically compares each `Addr` against
+// `Old` and iff all of them match, swaps them to `New`.
+// Words managed by CASN must be read with `CASNRead`.
+// It panics when an address appears more than once and
+// returns true when succesfull.
+func CASN(entries []CASNEntry) bool {
+	// Paper: A Practical Multi-Word Compare-and-Swap Operation
+	//        by Timothy L. Harris, Keir Fraser and Ian A. Pratt;
+	//        University of Cambridge Computer Laboratory, Cambridge,
+	//        UK.
+	if len(entries) == 0 {
+		return true
+	}
+	var (
+		desc *CASNDescriptor = &CASNDescriptor{
+			status:  casnUNDECIDED,
+			entries: make([]CASNEntry, len(entries)),
+		}
+	)
+	copy(desc.entries, entries)
+	// entries are processed in a global order to
+	// guarantee progress among conflicting operations.
+	sort.Slice(desc.entries, func(i, j int) bool {
+		return uintptr(unsafe.Pointer(desc.entries[i].Addr)) < uintptr(unsafe.Pointer(desc.entries[j].Addr))
+	})
+	for i := 1; i < len(desc.entries); i++ {
+		if desc.entries[i].Addr == desc.entries[i-1].Addr {
+			panic(ECASNADDR)
+		}
+	}
+	return casnHelp(desc)
+}
+
+// CASNRead atomically loads the value stored in `addr`.
+// Any in-flight `RDCSSDescriptor` or `CASNDescriptor`
+// found in `addr` is completed before the logical value
+// is returned.
+func CASNRead(addr *unsafe.Pointer) unsafe.Pointer {
+	var (
+		r unsafe.Pointer
+	)
+	for {
+		r = RDCSSRead(addr)
+		if !IsCASNDescriptor(r) {
+			return r
+		}
+		casnHelp((*CASNDescriptor)(Untag(r)))
+	}
+}
+
+// IsCASNDescriptor checks whether the given pointer
+// `addr` is pointing to `CASNDescriptor` or not. A
+// pointer is pointing to `CASNDescriptor` iff `0x2`
+// is present.
+func IsCASNDescriptor(addr unsafe.Pointer) bool {
+	return GetTag(addr) == casnTAG
+}
+
+// casnHelp drives descriptor `desc` to completion. In
+// the first phase, the descriptor is installed in each
+// address by RDCSS conditional to undecided status. The
+// second phase replaces the descriptor with new or old
+// values depending on the decided status. It is safe to
+// be called by any number of helpers.
+func casnHelp(desc *CASNDescriptor) bool {
+	var (
+		dptr   unsafe.Pointer
+		status unsafe.Pointer
+		val    unsafe.Pointer
+		entry  *CASNEntry
+	)
+	dptr, _ = TaggedPointer(unsafe.Pointer(desc), casnTAG)
+	if atomic.LoadPointer(&desc.status) == casnUNDECIDED {
+		status = casnSUCCEEDED
+		for i := 0; i < len(desc.entries) && status == casnSUCCEEDED; i++ {
+			entry = &desc.entries[i]
+			for {
+				val = rdcss(&RDCSSDescriptor{a1: &desc.status, o1: casnUNDECIDED, a2: entry.Addr, o2: entry.Old, n: dptr})
+				if IsCASNDescriptor(val) && val != dptr {
+					casnHelp((*CASNDescriptor)(Untag(val)))
+					continue
+				}
+				if !IsCASNDescriptor(val) && val != entry.Old {
+					status = casnFAILED
+				}
+				break
+			}
+		}
+		atomic.CompareAndSwapPointer(&desc.status, casnUNDECIDED, status)
+	}
+	var (
+		ok bool = atomic.LoadPointer(&desc.status) == casnSUCCEEDED
+	)
+	for i := range desc.entries {
+		entry = &desc.entries[i]
+		if ok {
+			atomic.CompareAndSwapPointer(entry.Addr, dptr, entry.New)
+		} else {
+			atomic.CompareAndSwapPointer(entry.Addr, dptr, entry.Old)
+		}
+	}
+	return ok
+}
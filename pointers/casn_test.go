@@ -0,0 +1,107 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package pointers
+
+import (
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestCASN(t *testing.T) {
+	var (
+		a, b, c    unsafe.Pointer = unsafe.Pointer(&Sample{1}), unsafe.Pointer(&Sample{2}), unsafe.Pointer(&Sample{3})
+		na, nb, nc unsafe.Pointer = unsafe.Pointer(&Sample{4}), unsafe.Pointer(&Sample{5}), unsafe.Pointer(&Sample{6})
+		wa, wb, wc unsafe.Pointer = a, b, c
+	)
+	if CASN([]CASNEntry{{&wa, a, na}, {&wb, nb, b}, {&wc, c, nc}}) {
+		t.Fatal("assertion failed, expected CASN to fail on mismatch.")
+	}
+	if CASNRead(&wa) != a || CASNRead(&wb) != b || CASNRead(&wc) != c {
+		t.Fatal("inconsistent state, failed CASN mutated words.")
+	}
+	if !CASN([]CASNEntry{{&wc, c, nc}, {&wa, a, na}, {&wb, b, nb}}) {
+		t.Fatal("inconsistent state, expected CASN to succeed.")
+	}
+	if CASNRead(&wa) != na || CASNRead(&wb) != nb || CASNRead(&wc) != nc {
+		t.Fatal("assertion failed, expected new values.")
+	}
+	if (*Sample)(CASNRead(&wb)).value != 5 {
+		t.Fatal("assertion failed, invalid pointer.")
+	}
+}
+
+func TestCASNDuplicate(t *testing.T) {
+	var (
+		a  unsafe.Pointer = unsafe.Pointer(&Sample{1})
+		wa unsafe.Pointer = a
+	)
+	defer func() {
+		if r := recover(); r != ECASNADDR {
+			t.Fatal("assertion failed, expected panic with ECASNADDR. got", r)
+		}
+	}()
+	CASN([]CASNEntry{{&wa, a, nil}, {&wa, a, nil}})
+}
+
+func TestCASNConcurrent(t *testing.T) {
+	const (
+		nwords   = 4
+		nworkers = 8
+		nrounds  = 500
+		maxvalue = nworkers * nrounds
+	)
+	var (
+		words  [nwords]unsafe.Pointer
+		values [maxvalue + 1]Sample
+		wg     sync.WaitGroup
+	)
+	for i := range values {
+		values[i].value = i
+	}
+	for i := range words {
+		words[i] = unsafe.Pointer(&values[0])
+	}
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var entries [nwords]CASNEntry
+			for n := 0; n < nrounds; {
+				old := (*Sample)(CASNRead(&words[0]))
+				for i := range entries {
+					entries[i] = CASNEntry{&words[i], unsafe.Pointer(old), unsafe.Pointer(&values[old.value+1])}
+				}
+				if CASN(entries[:]) {
+					n++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for i := range words {
+		if v := (*Sample)(CASNRead(&words[i])).value; v != maxvalue {
+			t.Fatalf("assertion failed, expected word(%d)==%d, got %d.", i, maxvalue, v)
+		}
+	}
+}
@@ -34,6 +34,24 @@ import (
 
 // - MARK: Multi-Word Compare-and-Swap Operation section.
 
+const (
+	// rdcssTAG is the low-order tag attached to
+	// `RDCSSDescriptor` pointers.
+	rdcssTAG uint = 0x1
+	// casnTAG is the low-order tag attached to
+	// `CASNDescriptor` pointers.
+	casnTAG uint = 0x2
+)
+
+// RDCSS outcomes. A `RDCSSDescriptor` starts in undecided
+// state and is decided exactly once by the first helper
+// completing it.
+const (
+	rdcssUNDECIDED uint32 = iota
+	rdcssSUCCEEDED
+	rdcssFAILED
+)
+
 // RDCSSDescriptor is descriptor for Multi-Word CAS. RDCSS
 // is defined as a restricted form of CAS2 operating atomi-
 // cally as follow:
@@ -53,6 +71,7 @@ type RDCSSDescriptor struct {
 	a2 *unsafe.Pointer // data address
 	o2 unsafe.Pointer  // old value
 	n  unsafe.Pointer  // new value
+	st uint32          // decided outcome
 }
 
 // RDCSS performs a Double-Compare Single-Swap atomic
@@ -69,11 +88,11 @@ func RDCSS(a1 *unsafe.Pointer, o1 unsafe.Pointer, a2 *unsafe.Pointer, o2 unsafe.
 	//        University of Cambridge Computer Laboratory, Cambridge,
 	//        UK.
 	var (
-		desc *RDCSSDescriptor = &RDCSSDescriptor{a1, o1, a2, o2, n}
+		desc *RDCSSDescriptor = &RDCSSDescriptor{a1: a1, o1: o1, a2: a2, o2: o2, n: n}
 		dptr unsafe.Pointer
 	)
 	// add `0x1` tag
-	dptr, _ = TaggedPointer(unsafe.Pointer(desc), 1)
+	dptr, _ = TaggedPointer(unsafe.Pointer(desc), rdcssTAG)
 	if atomic.CompareAndSwapPointer(
 		(*unsafe.Pointer)(unsafe.Pointer(desc.a2)),
 		(unsafe.Pointer)(desc.o2),
@@ -91,31 +110,57 @@ func RDCSS(a1 *unsafe.Pointer, o1 unsafe.Pointer, a2 *unsafe.Pointer, o2 unsafe.
 // In case of unsucessfull operation, `a2` is swapped with `o2` and
 // returns false. Note, `RDCSSDescriptor` pointers have a 0x1
 // tag attached to low-order bits.
+//
+// The outcome is decided once by the first caller and
+// every later caller ( helper ) reports the same outcome.
+// When `a2` no longer refers to the descriptor, another
+// helper has already restored or swapped it according
+// to the decided outcome.
 func RDCSSComplete(d unsafe.Pointer) bool {
 	var (
-		desc   *RDCSSDescriptor
-		tgdptr unsafe.Pointer = d
-		dptr   unsafe.Pointer = Untag(d)
+		desc    *RDCSSDescriptor
+		tgdptr  unsafe.Pointer = d
+		dptr    unsafe.Pointer = Untag(d)
+		outcome uint32         = rdcssFAILED
 	)
 	desc = (*RDCSSDescriptor)(dptr)
-	if (*desc.a1 == desc.o1) && atomic.CompareAndSwapPointer(
-		(*unsafe.Pointer)(unsafe.Pointer(desc.a2)),
-		(unsafe.Pointer)(unsafe.Pointer(tgdptr)),
-		(unsafe.Pointer)(desc.n),
-	) {
+	if atomic.LoadPointer(desc.a1) == desc.o1 {
+		outcome = rdcssSUCCEEDED
+	}
+	atomic.CompareAndSwapUint32(&desc.st, rdcssUNDECIDED, outcome)
+	if atomic.LoadUint32(&desc.st) == rdcssSUCCEEDED {
+		atomic.CompareAndSwapPointer(
+			(*unsafe.Pointer)(unsafe.Pointer(desc.a2)),
+			(unsafe.Pointer)(unsafe.Pointer(tgdptr)),
+			(unsafe.Pointer)(desc.n),
+		)
 		return true
 	}
-	if !atomic.CompareAndSwapPointer(
+	atomic.CompareAndSwapPointer(
 		(*unsafe.Pointer)(unsafe.Pointer(desc.a2)),
 		(unsafe.Pointer)(tgdptr),
 		(unsafe.Pointer)(desc.o2),
-	) {
-		// TODO
-		// . restore ( unable to restore case )
-	}
+	)
 	return false
 }
 
+// RDCSSRead atomically loads the value stored in `addr`.
+// When `addr` refers to an in-flight `RDCSSDescriptor`,
+// it helps completing the descriptor and retries until
+// a plain value is observed.
+func RDCSSRead(addr *unsafe.Pointer) unsafe.Pointer {
+	var (
+		r unsafe.Pointer
+	)
+	for {
+		r = atomic.LoadPointer(addr)
+		if !IsRDCSSDescriptor(r) {
+			return r
+		}
+		RDCSSComplete(r)
+	}
+}
+
 // IsRDCSSDescriptor checks whether the given pointer
 // `addr` is pointong to `RDCSSDescriptor`or not. According
 // to original paper ( Section 6.2 ), `RDCSSDescriptor`
@@ -123,5 +168,38 @@ func RDCSSComplete(d unsafe.Pointer) bool {
 // bits. A pointer is pointing to `RDCSSDescriptor` iff
 // `0x1` is present.
 func IsRDCSSDescriptor(addr unsafe.Pointer) bool {
-	return HasTag(addr)
+	return GetTag(addr) == rdcssTAG
+}
+
+// rdcss is the internal form of RDCSS as described in
+// the original paper. It installs descriptor `desc` in
+// `a2`, helps any conflicting `RDCSSDescriptor` found
+// in its way and returns the value observed in `a2`.
+// The operation has taken effect iff the returned value
+// is equal to `o2` and `a1` still holds `o1` once the
+// descriptor is completed.
+func rdcss(desc *RDCSSDescriptor) unsafe.Pointer {
+	var (
+		dptr unsafe.Pointer
+		r    unsafe.Pointer
+	)
+	dptr, _ = TaggedPointer(unsafe.Pointer(desc), rdcssTAG)
+	for {
+		if atomic.CompareAndSwapPointer(desc.a2, desc.o2, dptr) {
+			r = desc.o2
+			break
+		}
+		r = atomic.LoadPointer(desc.a2)
+		if IsRDCSSDescriptor(r) {
+			RDCSSComplete(r)
+			continue
+		}
+		if r != desc.o2 {
+			break
+		}
+	}
+	if r == desc.o2 {
+		RDCSSComplete(dptr)
+	}
+	return r
 }
@@ -0,0 +1,483 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package hashmap
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"reflect"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/mitghi/x/bit"
+	"github.com/mitghi/x/pointers"
+)
+
+// - MARK: Split-Ordered-Map section.
+
+const (
+	// maxLoad is the average number of items per
+	// bucket which triggers doubling the buckets.
+	maxLoad = 2
+	// minBuckets is the initial number of buckets.
+	minBuckets = 2
+	// markTAG is the low-order tag marking a node
+	// as logically deleted.
+	markTAG uint = 0x1
+)
+
+var (
+	// tombstone is the value of a deleted node. It is
+	// swapped in before the node is marked, hence a
+	// value is never written to a deleted node.
+	tombstone unsafe.Pointer = unsafe.Pointer(new(uint64))
+)
+
+// node is a list item. Dummy nodes mark the start of
+// buckets and have even split-order keys and a nil
+// value; regular nodes have odd split-order keys.
+type node[K comparable, V any] struct {
+	so    uint64         // split-order key
+	key   K              // immutable
+	value unsafe.Pointer // *V or tombstone
+	next  unsafe.Pointer // tagged *node[K, V]
+}
+
+// Map is a lock-free resizable hash map. All items live
+// in a single lock-free list sorted by bit-reversed hash
+// ( split-order ); buckets are shortcuts into the list
+// and are initialized lazily, hence doubling the number
+// of buckets never moves items. It must be created with
+// `New`.
+type Map[K comparable, V any] struct {
+	// Paper: Split-Ordered Lists: Lock-Free Extensible
+	//        Hash Tables by Ori Shalev and Nir Shavit;
+	//        Tel-Aviv University, Israel.
+	seed  maphash.Seed
+	head  *node[K, V]        // dummy of bucket 0
+	tail  *node[K, V]        // sentinel, larger than any key
+	dir   [65]unsafe.Pointer // *[]unsafe.Pointer segments
+	size  atomic.Uint64      // number of buckets, power of two
+	count atomic.Int64
+}
+
+// New allocates and initializes a new `Map` sized for
+// `capacity` items and returns a pointer to it. The
+// number of buckets is rounded up to a power of two.
+func New[K comparable, V any](capacity uint64) *Map[K, V] {
+	var (
+		m *Map[K, V] = &Map[K, V]{
+			seed: maphash.MakeSeed(),
+			tail: &node[K, V]{so: ^uint64(0)},
+		}
+	)
+	m.size.Store(max(bit.RoundNextP2(capacity/maxLoad), minBuckets))
+	m.head = &node[K, V]{next: unsafe.Pointer(m.tail)}
+	atomic.StorePointer(m.slot(0), unsafe.Pointer(m.head))
+	return m
+}
+
+// Load returns the value stored for `key`
+// along with true when present.
+func (m *Map[K, V]) Load(key K) (value V, ok bool) {
+	var (
+		curr *node[K, V]
+		cptr unsafe.Pointer
+	)
+	if _, curr, ok = m.lookup(key); !ok {
+		return value, false
+	}
+	if cptr = atomic.LoadPointer(&curr.value); cptr == tombstone {
+		return value, false
+	}
+	return *(*V)(cptr), true
+}
+
+// Store sets the value for `key`.
+func (m *Map[K, V]) Store(key K, value V) {
+	m.store(key, value, false)
+}
+
+// LoadOrStore returns the existing value for `key` along
+// with true when present. Otherwise, it stores `value`
+// and returns it along with false.
+func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return m.store(key, value, true)
+}
+
+// CompareAndSwap swaps the value of `key` from `old`
+// to `new` iff the stored value is equal to `old`. It
+// returns true when succesfull. Note, like `sync.Map`,
+// it panics when `V` is not comparable.
+func (m *Map[K, V]) CompareAndSwap(key K, old, new V) bool {
+	var (
+		curr *node[K, V]
+		cptr unsafe.Pointer
+		ok   bool
+	)
+	if _, curr, ok = m.lookup(key); !ok {
+		return false
+	}
+	for {
+		cptr = atomic.LoadPointer(&curr.value)
+		if cptr == tombstone || any(*(*V)(cptr)) != any(old) {
+			return false
+		}
+		if atomic.CompareAndSwapPointer(&curr.value, cptr, unsafe.Pointer(&new)) {
+			return true
+		}
+	}
+}
+
+// Delete removes `key` from the map. The key is deleted
+// once its value is swapped with `tombstone`; the node
+// is then marked and unlinked.
+func (m *Map[K, V]) Delete(key K) {
+	var (
+		hash       uint64 = m.hash(key)
+		start      *node[K, V]
+		pred, curr *node[K, V]
+		cptr, succ unsafe.Pointer
+		ok         bool
+	)
+	start = m.bucket(hash & (m.size.Load() - 1))
+	for {
+		if pred, curr, ok = m.find(start, regular(hash), key, false); !ok {
+			return
+		}
+		if cptr = atomic.LoadPointer(&curr.value); cptr == tombstone {
+			return
+		}
+		if atomic.CompareAndSwapPointer(&curr.value, cptr, tombstone) {
+			m.count.Add(-1)
+			succ = remove(curr)
+			if !atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(curr), succ) {
+				m.find(start, regular(hash), key, false)
+			}
+			return
+		}
+	}
+}
+
+// Range calls `fn` sequentially for each key and value
+// until `fn` returns false. Like `sync.Map`, it does not
+// correspond to a consistent snapshot.
+func (m *Map[K, V]) Range(fn func(key K, value V) bool) {
+	var (
+		curr       *node[K, V] = next(m.head)
+		cptr, succ unsafe.Pointer
+	)
+	for curr != m.tail {
+		succ = atomic.LoadPointer(&curr.next)
+		if curr.so&1 == 1 && !pointers.HasTag(succ) {
+			if cptr = atomic.LoadPointer(&curr.value); cptr != tombstone && !fn(curr.key, *(*V)(cptr)) {
+				return
+			}
+		}
+		curr = (*node[K, V])(pointers.Untag(succ))
+	}
+}
+
+// Len returns the number of items. Note, the
+// value is approximate under concurrent updates.
+func (m *Map[K, V]) Len() int {
+	return int(m.count.Load())
+}
+
+// store implements `Store` and `LoadOrStore`.
+func (m *Map[K, V]) store(key K, value V, keep bool) (actual V, loaded bool) {
+	var (
+		hash       uint64 = m.hash(key)
+		so         uint64 = regular(hash)
+		start      *node[K, V]
+		pred, curr *node[K, V]
+		n          *node[K, V]
+		cptr       unsafe.Pointer
+		size       uint64
+		ok         bool
+	)
+	start = m.bucket(hash & (m.size.Load() - 1))
+	for {
+		if pred, curr, ok = m.find(start, so, key, false); ok {
+			cptr = atomic.LoadPointer(&curr.value)
+			if cptr == tombstone {
+				// key is deleted, help marking the node
+				// so that `find` unlinks it, and retry.
+				remove(curr)
+				continue
+			}
+			if keep {
+				return *(*V)(cptr), true
+			}
+			if atomic.CompareAndSwapPointer(&curr.value, cptr, unsafe.Pointer(&value)) {
+				return value, false
+			}
+			continue
+		}
+		if n == nil {
+			n = &node[K, V]{so: so, key: key, value: unsafe.Pointer(&value)}
+		}
+		n.next = unsafe.Pointer(curr)
+		if atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(curr), unsafe.Pointer(n)) {
+			break
+		}
+	}
+	size = m.size.Load()
+	if uint64(m.count.Add(1)) > size*maxLoad && size < 1<<63 {
+		m.size.CompareAndSwap(size, size<<1)
+	}
+	return value, false
+}
+
+// lookup finds the node holding `key`.
+func (m *Map[K, V]) lookup(key K) (pred *node[K, V], curr *node[K, V], ok bool) {
+	var (
+		hash uint64 = m.hash(key)
+	)
+	return m.find(m.bucket(hash&(m.size.Load()-1)), regular(hash), key, false)
+}
+
+// find searches the list from `start` for a node with
+// split-order key `so` and `key` ( unless `dummy` ). It
+// returns adjacent unmarked nodes `pred` and `curr` where
+// `curr` is either the found node or the insertion point.
+// Marked nodes found in between are unlinked.
+func (m *Map[K, V]) find(start *node[K, V], so uint64, key K, dummy bool) (pred *node[K, V], curr *node[K, V], ok bool) {
+	var (
+		succ unsafe.Pointer
+	)
+RETRY:
+	pred = start
+	curr = next(pred)
+	for curr != m.tail {
+		succ = atomic.LoadPointer(&curr.next)
+		if pointers.HasTag(succ) {
+			if !atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(curr), pointers.Untag(succ)) {
+				goto RETRY
+			}
+			curr = (*node[K, V])(pointers.Untag(succ))
+			continue
+		}
+		if curr.so > so {
+			break
+		}
+		if curr.so == so && (dummy || curr.key == key) {
+			return pred, curr, true
+		}
+		pred, curr = curr, (*node[K, V])(succ)
+	}
+	return pred, curr, false
+}
+
+// bucket returns the dummy node of bucket `b`
+// and initializes it when required.
+func (m *Map[K, V]) bucket(b uint64) *node[K, V] {
+	var (
+		slot *unsafe.Pointer = m.slot(b)
+		d    unsafe.Pointer  = atomic.LoadPointer(slot)
+	)
+	if d != nil {
+		return (*node[K, V])(d)
+	}
+	var (
+		so         uint64      = bit.Reverse(b)
+		parent     *node[K, V] = m.bucket(b - bit.RoundPrevP2(b+1))
+		pred, curr *node[K, V]
+		n          *node[K, V] = &node[K, V]{so: so}
+		ok         bool
+		zero       K
+	)
+	// a bucket is split from its parent by inserting
+	// a dummy node in the parent bucket.
+	for {
+		if pred, curr, ok = m.find(parent, so, zero, true); ok {
+			n = curr
+			break
+		}
+		n.next = unsafe.Pointer(curr)
+		if atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(curr), unsafe.Pointer(n)) {
+			break
+		}
+	}
+	if !atomic.CompareAndSwapPointer(slot, nil, unsafe.Pointer(n)) {
+		return (*node[K, V])(atomic.LoadPointer(slot))
+	}
+	return n
+}
+
+// slot returns address of bucket `b` in the segment
+// directory. Segment `i` holds buckets in range
+// [2^(i-1), 2^i) and is allocated lazily.
+func (m *Map[K, V]) slot(b uint64) *unsafe.Pointer {
+	var (
+		i   int            = bits.Len64(b)
+		seg unsafe.Pointer = atomic.LoadPointer(&m.dir[i])
+		lo  uint64
+	)
+	if i > 0 {
+		lo = 1 << (i - 1)
+	}
+	if seg == nil {
+		var (
+			n uint64 = lo
+		)
+		if n == 0 {
+			n = 1
+		}
+		buckets := make([]unsafe.Pointer, n)
+		if !atomic.CompareAndSwapPointer(&m.dir[i], nil, unsafe.Pointer(&buckets)) {
+			seg = atomic.LoadPointer(&m.dir[i])
+		} else {
+			seg = unsafe.Pointer(&buckets)
+		}
+	}
+	return &(*(*[]unsafe.Pointer)(seg))[b-lo]
+}
+
+// hash returns the hash of `key`. Equal keys have
+// equal hashes; strings and integers are hashed
+// directly and other keys through `writeComparable`.
+func (m *Map[K, V]) hash(key K) uint64 {
+	var (
+		buf [8]byte
+		h   maphash.Hash
+	)
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(m.seed, k)
+	case int:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case int32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case uint:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case uint64:
+		binary.LittleEndian.PutUint64(buf[:], k)
+	case uint32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case uintptr:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	default:
+		h.SetSeed(m.seed)
+		writeComparable(&h, reflect.ValueOf(&key).Elem())
+		return h.Sum64()
+	}
+	return maphash.Bytes(m.seed, buf[:])
+}
+
+// writeComparable writes `v` to `h` such that values
+// which are equal under `==` write the same bytes.
+func writeComparable(h *maphash.Hash, v reflect.Value) {
+	var (
+		buf [8]byte
+	)
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			buf[0] = 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		binary.LittleEndian.PutUint64(buf[:], v.Uint())
+	case reflect.Float32, reflect.Float64:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(real(v.Complex())))
+		h.Write(buf[:])
+		binary.LittleEndian.PutUint64(buf[:], floatBits(imag(v.Complex())))
+	case reflect.String:
+		h.WriteString(v.String())
+		return
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v.Pointer()))
+	case reflect.Interface:
+		if !v.IsNil() {
+			writeComparable(h, v.Elem())
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			writeComparable(h, v.Index(i))
+		}
+		return
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			// blank fields are ignored by `==`.
+			if v.Type().Field(i).Name != "_" {
+				writeComparable(h, v.Field(i))
+			}
+		}
+		return
+	default:
+		panic("hashmap: hash of unhashable type " + v.Type().String())
+	}
+	h.Write(buf[:])
+}
+
+// floatBits returns bits of `f` with -0 and +0
+// mapped to the same value.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
+
+// regular returns the split-order key of a regular
+// node; the most significant bit of `hash` is set
+// so that the reversed key is odd.
+func regular(hash uint64) uint64 {
+	return bit.Reverse(hash | 1<<63)
+}
+
+// next returns the successor of `n`.
+func next[K comparable, V any](n *node[K, V]) *node[K, V] {
+	return (*node[K, V])(pointers.Untag(atomic.LoadPointer(&n.next)))
+}
+
+// remove marks `n` as deleted by tagging its next
+// pointer and returns the untagged successor.
+func remove[K comparable, V any](n *node[K, V]) unsafe.Pointer {
+	var (
+		succ unsafe.Pointer
+	)
+	for {
+		succ = atomic.LoadPointer(&n.next)
+		if pointers.HasTag(succ) {
+			return pointers.Untag(succ)
+		}
+		if atomic.CompareAndSwapPointer(&n.next, succ, mark(succ)) {
+			return succ
+		}
+	}
+}
+
+// mark returns `ptr` tagged as deleted.
+func mark(ptr unsafe.Pointer) unsafe.Pointer {
+	ptr, _ = pointers.TaggedPointer(ptr, markTAG)
+	return ptr
+}
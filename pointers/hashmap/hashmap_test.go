@@ -0,0 +1,258 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package hashmap
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/mitghi/x/structs"
+)
+
+func TestMap(t *testing.T) {
+	var (
+		m *Map[string, int] = New[string, int](0)
+		n int
+	)
+	for i := 0; i < 1024; i++ {
+		m.Store(strconv.Itoa(i), i)
+	}
+	if m.Len() != 1024 {
+		t.Fatal("assertion failed, expected Len()==1024. got", m.Len())
+	}
+	if m.size.Load() < 512 {
+		t.Fatal("assertion failed, expected buckets to grow. got", m.size.Load())
+	}
+	for i := 0; i < 1024; i++ {
+		if v, ok := m.Load(strconv.Itoa(i)); !ok || v != i {
+			t.Fatalf("assertion failed, expected Load(%d)==%d. got %d, %t.", i, i, v, ok)
+		}
+	}
+	if _, ok := m.Load("missing"); ok {
+		t.Fatal("assertion failed, expected missing key.")
+	}
+	m.Store("7", 70)
+	if v, _ := m.Load("7"); v != 70 {
+		t.Fatal("assertion failed, expected Load(7)==70. got", v)
+	}
+	if v, loaded := m.LoadOrStore("7", 700); !loaded || v != 70 {
+		t.Fatal("assertion failed, expected LoadOrStore(7)==70, true. got", v, loaded)
+	}
+	if v, loaded := m.LoadOrStore("x", 1); loaded || v != 1 {
+		t.Fatal("assertion failed, expected LoadOrStore(x)==1, false. got", v, loaded)
+	}
+	if m.CompareAndSwap("7", 7, 8) || !m.CompareAndSwap("7", 70, 8) || m.CompareAndSwap("y", 0, 1) {
+		t.Fatal("inconsistent state, unexpected CompareAndSwap result.")
+	}
+	m.Delete("7")
+	m.Delete("x")
+	m.Delete("missing")
+	if _, ok := m.Load("7"); ok {
+		t.Fatal("assertion failed, expected deleted key.")
+	}
+	m.Range(func(key string, value int) bool {
+		if strconv.Itoa(value) != key {
+			t.Fatalf("inconsistent state, invalid entry %s=%d.", key, value)
+		}
+		n++
+		return true
+	})
+	if n != 1023 || m.Len() != 1023 {
+		t.Fatalf("assertion failed, expected 1023 items. got %d (Len()==%d).", n, m.Len())
+	}
+}
+
+func TestMapConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nkeys    = 4096
+	)
+	var (
+		m  *Map[int, int] = New[int, int](16)
+		wg sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < nkeys; i += nworkers {
+				m.Store(i, i)
+				if v, loaded := m.LoadOrStore(i, -1); !loaded || v != i {
+					t.Errorf("inconsistent state, LoadOrStore(%d)==%d, %t.", i, v, loaded)
+				}
+				if i%2 == 0 {
+					m.Delete(i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for i := 0; i < nkeys; i++ {
+		v, ok := m.Load(i)
+		if ok != (i%2 == 1) || (ok && v != i) {
+			t.Fatalf("inconsistent state, Load(%d)==%d, %t.", i, v, ok)
+		}
+	}
+	if m.Len() != nkeys/2 {
+		t.Fatalf("assertion failed, expected Len()==%d. got %d.", nkeys/2, m.Len())
+	}
+}
+
+func TestMapStoreDelete(t *testing.T) {
+	const (
+		nworkers = 4
+		nkeys    = 8
+		nrounds  = 4096
+	)
+	var (
+		m  *Map[int, int] = New[int, int](0)
+		wg sync.WaitGroup
+		n  int
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < nrounds; i++ {
+				k := (i + w) % nkeys
+				v := k + nkeys*i
+				m.Store(k, v)
+				m.CompareAndSwap(k, v, v+nkeys)
+				if v, ok := m.Load(k); ok && v%nkeys != k {
+					t.Errorf("inconsistent state, Load(%d)==%d.", k, v)
+				}
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < nrounds; i++ {
+				m.Delete((i + w) % nkeys)
+			}
+		}(w)
+	}
+	wg.Wait()
+	for k := 0; k < nkeys; k++ {
+		if _, ok := m.Load(k); ok {
+			n++
+		}
+	}
+	if m.Len() != n {
+		t.Fatalf("inconsistent state, Len()==%d with %d keys.", m.Len(), n)
+	}
+	// a key stored after every delete returned
+	// must be visible.
+	for k := 0; k < nkeys; k++ {
+		m.Store(k, k)
+		if v, ok := m.Load(k); !ok || v != k {
+			t.Fatalf("assertion failed, expected Load(%d)==%d. got %d, %t.", k, k, v, ok)
+		}
+	}
+	if m.Len() != nkeys {
+		t.Fatalf("assertion failed, expected Len()==%d. got %d.", nkeys, m.Len())
+	}
+}
+
+func TestMapHash(t *testing.T) {
+	type key struct {
+		a float64
+		_ int
+		s string
+		i any
+	}
+	var (
+		m *Map[key, int] = New[key, int](0)
+		z float64
+	)
+	m.Store(key{a: 0, s: "x", i: 1}, 1)
+	if v, ok := m.Load(key{a: -z, s: "x", i: 1}); !ok || v != 1 {
+		t.Fatal("assertion failed, expected equal keys to hash equally.")
+	}
+	if _, ok := m.Load(key{a: 0, s: "y", i: 1}); ok {
+		t.Fatal("assertion failed, expected missing key.")
+	}
+}
+
+func TestMapAlign(t *testing.T) {
+	for _, arch := range []string{"386", "arm", "mips"} {
+		l, err := structs.LayoutFor(Map[string, int]{}, arch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(l.Unaligned64) != 0 {
+			t.Fatalf("assertion failed, expected aligned 64-bit fields on %s. got %v.", arch, l.Unaligned64)
+		}
+	}
+}
+
+func BenchmarkMapLoad(b *testing.B) {
+	var (
+		m *Map[int, int] = New[int, int](0)
+	)
+	for i := 0; i < 1024; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Load(i & 1023)
+		}
+	})
+}
+
+func BenchmarkSyncMapLoad(b *testing.B) {
+	var (
+		m sync.Map
+	)
+	for i := 0; i < 1024; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Load(i & 1023)
+		}
+	})
+}
+
+func BenchmarkMapStore(b *testing.B) {
+	var (
+		m *Map[int, int] = New[int, int](0)
+	)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Store(i&1023, i)
+		}
+	})
+}
+
+func BenchmarkSyncMapStore(b *testing.B) {
+	var (
+		m sync.Map
+	)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Store(i&1023, i)
+		}
+	})
+}
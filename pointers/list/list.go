@@ -0,0 +1,211 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package list
+
+import (
+	"cmp"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/mitghi/x/pointers"
+)
+
+// - MARK: Harris-List section.
+
+// markTAG is the low-order tag marking a node
+// as logically deleted; it is attached to the
+// `next` pointer of the deleted node.
+const markTAG uint = 0x1
+
+// node is a list item.
+type node[K cmp.Ordered] struct {
+	key  K
+	next unsafe.Pointer // tagged *node[K]
+}
+
+// Set is a lock-free sorted set. Nodes are deleted
+// logically by marking their `next` pointer and are
+// unlinked physically by any subsequent traversal.
+// It must be created with `New`.
+type Set[K cmp.Ordered] struct {
+	// Paper: A Pragmatic Implementation of Non-Blocking
+	//        Linked-Lists by Timothy L. Harris;
+	//        University of Cambridge Computer Laboratory,
+	//        Cambridge, UK.
+	head *node[K] // sentinel, smaller than any key
+	tail *node[K] // sentinel, larger than any key
+	len  int64
+}
+
+// New allocates and initializes a new
+// `Set` and returns a pointer to it.
+func New[K cmp.Ordered]() *Set[K] {
+	var (
+		s *Set[K] = &Set[K]{tail: &node[K]{}}
+	)
+	s.head = &node[K]{next: unsafe.Pointer(s.tail)}
+	return s
+}
+
+// Insert adds `key` to the set. It returns
+// false when `key` is already present.
+func (s *Set[K]) Insert(key K) bool {
+	var (
+		n           *node[K] = &node[K]{key: key}
+		pred, right *node[K]
+	)
+	for {
+		pred, right = s.search(key)
+		if right != s.tail && right.key == key {
+			return false
+		}
+		n.next = unsafe.Pointer(right)
+		if atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(right), unsafe.Pointer(n)) {
+			atomic.AddInt64(&s.len, 1)
+			return true
+		}
+	}
+}
+
+// Delete removes `key` from the set. It returns
+// false when `key` is not present.
+func (s *Set[K]) Delete(key K) bool {
+	var (
+		pred, right *node[K]
+		succ        unsafe.Pointer
+	)
+	for {
+		pred, right = s.search(key)
+		if right == s.tail || right.key != key {
+			return false
+		}
+		succ = atomic.LoadPointer(&right.next)
+		if pointers.HasTag(succ) {
+			// concurrently deleted, retry so that
+			// search unlinks it.
+			continue
+		}
+		if atomic.CompareAndSwapPointer(&right.next, succ, mark(succ)) {
+			atomic.AddInt64(&s.len, -1)
+			if !atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(right), succ) {
+				s.search(key)
+			}
+			return true
+		}
+	}
+}
+
+// Contains returns whether `key` is present.
+// It does not modify the list.
+func (s *Set[K]) Contains(key K) bool {
+	var (
+		curr *node[K] = s.next(s.head)
+	)
+	for curr != s.tail && curr.key < key {
+		curr = s.next(curr)
+	}
+	return curr != s.tail && curr.key == key && !pointers.HasTag(atomic.LoadPointer(&curr.next))
+}
+
+// Len returns the number of keys. Note, the
+// value is approximate under concurrent updates.
+func (s *Set[K]) Len() int {
+	return int(atomic.LoadInt64(&s.len))
+}
+
+// Range calls `fn` sequentially for each key in
+// ascending order until `fn` returns false. Keys
+// inserted or deleted concurrently may or may
+// not be visited.
+func (s *Set[K]) Range(fn func(key K) bool) {
+	s.walk(s.next(s.head), fn)
+}
+
+// RangeBetween is similar to `Range` but only visits
+// keys in range [`lo`, `hi`).
+func (s *Set[K]) RangeBetween(lo, hi K, fn func(key K) bool) {
+	var (
+		curr *node[K] = s.next(s.head)
+	)
+	for curr != s.tail && curr.key < lo {
+		curr = s.next(curr)
+	}
+	s.walk(curr, func(key K) bool {
+		return key < hi && fn(key)
+	})
+}
+
+// walk visits unmarked nodes starting from `curr`.
+func (s *Set[K]) walk(curr *node[K], fn func(key K) bool) {
+	var (
+		succ unsafe.Pointer
+	)
+	for curr != s.tail {
+		succ = atomic.LoadPointer(&curr.next)
+		if !pointers.HasTag(succ) && !fn(curr.key) {
+			return
+		}
+		curr = (*node[K])(pointers.Untag(succ))
+	}
+}
+
+// search returns adjacent unmarked nodes `pred` and
+// `right` such that `pred.key` < `key` <= `right.key`.
+// Marked nodes found in between are unlinked.
+func (s *Set[K]) search(key K) (pred *node[K], right *node[K]) {
+	var (
+		curr *node[K]
+		succ unsafe.Pointer
+	)
+RETRY:
+	pred = s.head
+	curr = s.next(pred)
+	for curr != s.tail {
+		succ = atomic.LoadPointer(&curr.next)
+		if pointers.HasTag(succ) {
+			// `curr` is logically deleted; unlink it,
+			// which fails when `pred` is deleted too.
+			if !atomic.CompareAndSwapPointer(&pred.next, unsafe.Pointer(curr), pointers.Untag(succ)) {
+				goto RETRY
+			}
+			curr = (*node[K])(pointers.Untag(succ))
+			continue
+		}
+		if curr.key >= key {
+			break
+		}
+		pred, curr = curr, (*node[K])(succ)
+	}
+	return pred, curr
+}
+
+// next returns the successor of `n`.
+func (s *Set[K]) next(n *node[K]) *node[K] {
+	return (*node[K])(pointers.Untag(atomic.LoadPointer(&n.next)))
+}
+
+// mark returns `ptr` tagged as deleted.
+func mark(ptr unsafe.Pointer) unsafe.Pointer {
+	ptr, _ = pointers.TaggedPointer(ptr, markTAG)
+	return ptr
+}
@@ -0,0 +1,116 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package list
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	var (
+		s    *Set[int] = New[int]()
+		keys []int
+	)
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		if !s.Insert(k) {
+			t.Fatalf("inconsistent state, cannot insert %d.", k)
+		}
+	}
+	if s.Insert(3) {
+		t.Fatal("assertion failed, expected duplicate insert to fail.")
+	}
+	if s.Len() != 5 {
+		t.Fatal("assertion failed, expected Len()==5. got", s.Len())
+	}
+	if !s.Contains(7) || s.Contains(4) {
+		t.Fatal("assertion failed, unexpected Contains result.")
+	}
+	if !s.Delete(7) || s.Delete(7) || s.Contains(7) {
+		t.Fatal("inconsistent state, unexpected Delete result.")
+	}
+	s.Range(func(key int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 4 || keys[0] != 1 || keys[1] != 3 || keys[2] != 5 || keys[3] != 9 {
+		t.Fatal("assertion failed, expected [1 3 5 9]. got", keys)
+	}
+	keys = keys[:0]
+	s.RangeBetween(2, 9, func(key int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 2 || keys[0] != 3 || keys[1] != 5 {
+		t.Fatal("assertion failed, expected [3 5]. got", keys)
+	}
+}
+
+func TestSetConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nkeys    = 512
+	)
+	var (
+		s        *Set[int] = New[int]()
+		inserted [nworkers]int
+		deleted  [nworkers]int
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < nkeys*4; i++ {
+				k := (i*7 + w) % nkeys
+				if i%3 == 0 {
+					if s.Delete(k) {
+						deleted[w]++
+					}
+				} else if s.Insert(k) {
+					inserted[w]++
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	var (
+		total int
+		last  int = -1
+		n     int
+	)
+	for w := range inserted {
+		total += inserted[w] - deleted[w]
+	}
+	s.Range(func(key int) bool {
+		if key <= last {
+			t.Fatalf("inconsistent state, unordered keys %d after %d.", key, last)
+		}
+		last = key
+		n++
+		return true
+	})
+	if n != total || s.Len() != total {
+		t.Fatalf("inconsistent state, expected %d keys. got %d (Len()==%d).", total, n, s.Len())
+	}
+}
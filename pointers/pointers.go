@@ -29,6 +29,9 @@ import (
 
 // - MARK: Atomics section.
 
+// Note, functions in this section operate on raw
+// addresses; `AtomicArray` is the type-safe alternative.
+
 // CASSliceSlot is a function that performs a CAS operation
 // on a given slice slot by performing pointer arithmitic
 // to find slot address. `addr` is a pointer to slice,
@@ -37,17 +40,7 @@ import (
 // the slot number and `ptrsize` is the slice value size.
 // It returns true when succesfull.
 func CASSliceSlot(addr unsafe.Pointer, data unsafe.Pointer, target unsafe.Pointer, index int, ptrsize uintptr) bool {
-	var (
-		tptr *unsafe.Pointer
-		cptr unsafe.Pointer
-	)
-	tptr = (*unsafe.Pointer)(unsafe.Pointer(*(*uintptr)(addr) + (ptrsize * uintptr(index))))
-	cptr = unsafe.Pointer(tptr)
-	return atomic.CompareAndSwapPointer(
-		(*unsafe.Pointer)(unsafe.Pointer(cptr)),
-		(unsafe.Pointer)(unsafe.Pointer(target)),
-		(unsafe.Pointer)(unsafe.Pointer(data)),
-	)
+	return CASArraySlot(sliceData(addr), data, target, index, ptrsize)
 }
 
 // CASSliceSlotPtr is a function that performs a CAS operation
@@ -58,17 +51,7 @@ func CASSliceSlot(addr unsafe.Pointer, data unsafe.Pointer, target unsafe.Pointe
 // the slot number and `ptrsize` is the slice value size.
 // It returns true when succesfull.
 func CASSliceSlotPtr(addr unsafe.Pointer, data unsafe.Pointer, target unsafe.Pointer, index int, ptrsize uintptr) bool {
-	var (
-		tptr *unsafe.Pointer
-		cptr unsafe.Pointer
-	)
-	tptr = (*unsafe.Pointer)(unsafe.Pointer((uintptr)(addr) + (ptrsize * uintptr(index))))
-	cptr = unsafe.Pointer(tptr)
-	return atomic.CompareAndSwapPointer(
-		(*unsafe.Pointer)(unsafe.Pointer(cptr)),
-		(unsafe.Pointer)(unsafe.Pointer(target)),
-		(unsafe.Pointer)(unsafe.Pointer(data)),
-	)
+	return CASArraySlot(addr, data, target, index, ptrsize)
 }
 
 // CASArraySlot is a function that performs a CAS operation
@@ -79,30 +62,21 @@ func CASSliceSlotPtr(addr unsafe.Pointer, data unsafe.Pointer, target unsafe.Poi
 // the slot number and `ptrsize` is the slice value size.
 // It returns true when succesfull.
 func CASArraySlot(addr unsafe.Pointer, data unsafe.Pointer, target unsafe.Pointer, index int, ptrsize uintptr) bool {
-	var (
-		tptr *unsafe.Pointer
-		cptr unsafe.Pointer
-	)
-	tptr = (*unsafe.Pointer)(unsafe.Pointer((uintptr)(addr) + (ptrsize * uintptr(index))))
-	cptr = unsafe.Pointer(tptr)
-	return atomic.CompareAndSwapPointer((*unsafe.Pointer)(unsafe.Pointer(cptr)),
-		(unsafe.Pointer)(unsafe.Pointer(target)),
-		(unsafe.Pointer)(unsafe.Pointer(data)),
-	)
+	return atomic.CompareAndSwapPointer(slotAddr(addr, index, ptrsize), target, data)
 }
 
 // OffsetArraySlot takes a array pointer and returns
 // slot address by adding `index` times `ptrsize` bytes
 // to slice data pointer.
 func OffsetArraySlot(addr unsafe.Pointer, index int, ptrsize uintptr) unsafe.Pointer {
-	return unsafe.Pointer((*unsafe.Pointer)(unsafe.Pointer((uintptr)(addr) + (ptrsize * uintptr(index)))))
+	return unsafe.Pointer(slotAddr(addr, index, ptrsize))
 }
 
 // OffsetSliceSlot takes a slice pointer and returns
 // slot address by adding `index` times `ptrsize` bytes
 // to slice data pointer.
 func OffsetSliceSlot(addr unsafe.Pointer, index int, ptrsize uintptr) unsafe.Pointer {
-	return unsafe.Pointer(*(*uintptr)(addr) + (ptrsize * uintptr(index)))
+	return OffsetArraySlot(sliceData(addr), index, ptrsize)
 }
 
 // SetSliceSlot is a wrapper function that writes `d`
@@ -139,22 +113,14 @@ func SetArraySlot(addr unsafe.Pointer, index int, ptrsize uintptr, d unsafe.Poin
 // slot address by adding `index` times `ptrsize` bytes
 // to slice data pointer.
 func LoadArraySlot(addr unsafe.Pointer, index int, ptrsize uintptr) unsafe.Pointer {
-	var (
-		tptr *unsafe.Pointer
-	)
-	tptr = (*unsafe.Pointer)(unsafe.Pointer((uintptr)(addr) + (ptrsize * uintptr(index))))
-	return atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(tptr)))
+	return atomic.LoadPointer(slotAddr(addr, index, ptrsize))
 }
 
 // LoadSliceSlot takes a slice pointer and loads
 // slot address by adding `index` times `ptrsize` bytes
 // to slice data pointer.
 func LoadSliceSlot(addr unsafe.Pointer, index int, ptrsize uintptr) unsafe.Pointer {
-	var (
-		bin *unsafe.Pointer
-	)
-	bin = (*unsafe.Pointer)(unsafe.Pointer(*(*uintptr)(addr) + (ptrsize * uintptr(index))))
-	return atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(bin)))
+	return LoadArraySlot(sliceData(addr), index, ptrsize)
 }
 
 // PopArraySlot is a wrapper function that pops
@@ -175,14 +141,7 @@ func PopArraySlot(addr unsafe.Pointer, index int, ptrsize uintptr) (unsafe.Point
 // `index` slot of slice iff its nil. It returns
 // a pointer and true when succesfull.
 func PopSliceSlot(addr unsafe.Pointer, index int, ptrsize uintptr) (unsafe.Pointer, bool) {
-	var (
-		slot unsafe.Pointer = LoadSliceSlot(addr, index, ptrsize)
-	)
-	if !CASSliceSlot(addr, nil, slot, index, ptrsize) {
-		return nil, false
-	}
-
-	return slot, true
+	return PopArraySlot(sliceData(addr), index, ptrsize)
 }
 
 // CompareAndSwapPointerTag performs CAS operation
@@ -210,3 +169,15 @@ func CompareAndSwapPointerTag(source unsafe.Pointer, oldtag uint, newtag uint) (
 
 	return nil, false
 }
+
+// slotAddr returns address of `index` slot of the
+// array pointed by `addr` with `ptrsize` sized slots.
+func slotAddr(addr unsafe.Pointer, index int, ptrsize uintptr) *unsafe.Pointer {
+	return (*unsafe.Pointer)(unsafe.Pointer(uintptr(addr) + (ptrsize * uintptr(index))))
+}
+
+// sliceData returns data pointer of the slice
+// pointed by `addr`.
+func sliceData(addr unsafe.Pointer) unsafe.Pointer {
+	return *(*unsafe.Pointer)(addr)
+}
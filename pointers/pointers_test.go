@@ -24,6 +24,7 @@ package pointers
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"unsafe"
 )
@@ -249,7 +250,7 @@ func TestRDCSS2x(t *testing.T) {
 		rdiPtr  unsafe.Pointer = unsafe.Pointer(&ring.rdi)
 		vptr    **tstsample    // value pointer
 	)
-	if !SetSliceSlot(unsafe.Pointer(&ring.nodes), 1, pointers.ArchPTRSIZE, unsafe.Pointer(&s)) {
+	if !SetSliceSlot(unsafe.Pointer(&ring.nodes), 1, ArchPTRSIZE, unsafe.Pointer(&s)) {
 		t.Fatal("inconsistent state, can't write to slice/slot.")
 	}
 	if ring.nodes[1] == nil {
@@ -303,3 +304,97 @@ func TestRDCSS2x(t *testing.T) {
 	}
 	fmt.Println("slotptr:", *(**tstsample)(slotptr), "addr:", slotptr, &n, ring.nodes, ring.nodes[1])
 }
+
+func TestRDCSSOutcome(t *testing.T) {
+	var (
+		on, off *Sample        = &Sample{1}, &Sample{0}
+		o, n    *Sample        = &Sample{64}, &Sample{128}
+		ctrl    unsafe.Pointer = unsafe.Pointer(off)
+		data    unsafe.Pointer = unsafe.Pointer(o)
+		desc    *RDCSSDescriptor
+		dptr    unsafe.Pointer
+	)
+	if RDCSS(&ctrl, unsafe.Pointer(on), &data, unsafe.Pointer(o), unsafe.Pointer(n)) {
+		t.Fatal("assertion failed, expected RDCSS to fail on control mismatch.")
+	}
+	if RDCSSRead(&data) != unsafe.Pointer(o) {
+		t.Fatal("inconsistent state, expected data to be restored.")
+	}
+	// complete the same descriptor by several helpers.
+	desc = &RDCSSDescriptor{a1: &ctrl, o1: unsafe.Pointer(off), a2: &data, o2: unsafe.Pointer(o), n: unsafe.Pointer(n)}
+	dptr, _ = TaggedPointer(unsafe.Pointer(desc), rdcssTAG)
+	data = dptr
+	if RDCSSRead(&data) != unsafe.Pointer(n) {
+		t.Fatal("assertion failed, expected reader to complete the descriptor.")
+	}
+	ctrl = unsafe.Pointer(on)
+	if !RDCSSComplete(dptr) {
+		t.Fatal("inconsistent state, expected late helper to report decided outcome.")
+	}
+	if data != unsafe.Pointer(n) {
+		t.Fatal("inconsistent state, late helper mutated data.")
+	}
+}
+
+func TestRDCSSRead(t *testing.T) {
+	const (
+		nwriters = 4
+		nreaders = 4
+		nrounds  = 1000
+	)
+	var (
+		on     *Sample        = &Sample{1}
+		ctrl   unsafe.Pointer = unsafe.Pointer(on)
+		values [nwriters*nrounds + 1]Sample
+		data   unsafe.Pointer = unsafe.Pointer(&values[0])
+		done   chan struct{}  = make(chan struct{})
+		wg     sync.WaitGroup
+		rwg    sync.WaitGroup
+	)
+	for i := range values {
+		values[i].value = i
+	}
+	for r := 0; r < nreaders; r++ {
+		rwg.Add(1)
+		go func() {
+			defer rwg.Done()
+			var last int
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				v := RDCSSRead(&data)
+				if HasTag(v) {
+					t.Error("assertion failed, reader observed a descriptor.")
+					return
+				}
+				curr := (*Sample)(v).value
+				if curr < last {
+					t.Errorf("inconsistent state, value went backward %d->%d.", last, curr)
+					return
+				}
+				last = curr
+			}
+		}()
+	}
+	for w := 0; w < nwriters; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < nrounds; {
+				old := (*Sample)(RDCSSRead(&data))
+				if RDCSS(&ctrl, unsafe.Pointer(on), &data, unsafe.Pointer(old), unsafe.Pointer(&values[old.value+1])) {
+					n++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	rwg.Wait()
+	if v := (*Sample)(RDCSSRead(&data)).value; v != nwriters*nrounds {
+		t.Fatalf("assertion failed, expected %d, got %d.", nwriters*nrounds, v)
+	}
+}
@@ -0,0 +1,140 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package queue
+
+import (
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+)
+
+// - MARK: Michael-Scott-Queue section.
+
+// node is a queue item. The node referenced
+// by `head` is always a sentinel ( dummy ).
+type node[T any] struct {
+	value T
+	next  unsafe.Pointer // *node[T]
+}
+
+// Queue is a lock-free unbounded FIFO queue.
+// It must be created with `New`.
+type Queue[T any] struct {
+	// Paper: Simple, Fast, and Practical Non-Blocking and
+	//        Blocking Concurrent Queue Algorithms
+	//        by Maged M. Michael and Michael L. Scott;
+	//        University of Rochester, Rochester, NY.
+	head unsafe.Pointer // *node[T]
+	tail unsafe.Pointer // *node[T]
+	len  int64
+}
+
+// New allocates and initializes a new
+// `Queue` and returns a pointer to it.
+func New[T any]() *Queue[T] {
+	var (
+		sentinel unsafe.Pointer = unsafe.Pointer(&node[T]{})
+	)
+	return &Queue[T]{head: sentinel, tail: sentinel}
+}
+
+// Enqueue appends `v` to the tail of the queue.
+func (q *Queue[T]) Enqueue(v T) {
+	var (
+		n    unsafe.Pointer = unsafe.Pointer(&node[T]{value: v})
+		tail unsafe.Pointer
+		next unsafe.Pointer
+	)
+	for {
+		tail = atomic.LoadPointer(&q.tail)
+		next = atomic.LoadPointer(&(*node[T])(tail).next)
+		if tail != atomic.LoadPointer(&q.tail) {
+			continue
+		}
+		if next != nil {
+			// tail is lagging behind, help
+			// advancing it and retry.
+			atomic.CompareAndSwapPointer(&q.tail, tail, next)
+			continue
+		}
+		if atomic.CompareAndSwapPointer(&(*node[T])(tail).next, nil, n) {
+			atomic.CompareAndSwapPointer(&q.tail, tail, n)
+			atomic.AddInt64(&q.len, 1)
+			return
+		}
+	}
+}
+
+// Dequeue removes the head item and returns it
+// along with true. It does not block and returns
+// false when the queue is empty.
+func (q *Queue[T]) Dequeue() (value T, ok bool) {
+	var (
+		head unsafe.Pointer
+		tail unsafe.Pointer
+		next unsafe.Pointer
+		n    *node[T]
+		zero T
+	)
+	for {
+		head = atomic.LoadPointer(&q.head)
+		tail = atomic.LoadPointer(&q.tail)
+		next = atomic.LoadPointer(&(*node[T])(head).next)
+		if head != atomic.LoadPointer(&q.head) {
+			continue
+		}
+		if head == tail {
+			if next == nil {
+				return value, false
+			}
+			atomic.CompareAndSwapPointer(&q.tail, tail, next)
+			continue
+		}
+		if atomic.CompareAndSwapPointer(&q.head, head, next) {
+			// `next` is the new sentinel; release
+			// its value to the garbage collector.
+			n = (*node[T])(next)
+			value, n.value = n.value, zero
+			atomic.AddInt64(&q.len, -1)
+			return value, true
+		}
+	}
+}
+
+// TryDequeue is similar to `Dequeue` but retries
+// up to `spins` times, yielding the processor
+// between attempts, while the queue is empty.
+func (q *Queue[T]) TryDequeue(spins int) (value T, ok bool) {
+	for i := 0; ; i++ {
+		if value, ok = q.Dequeue(); ok || i >= spins {
+			return value, ok
+		}
+		runtime.Gosched()
+	}
+}
+
+// Len returns the number of items. Note, the
+// value is approximate under concurrent updates.
+func (q *Queue[T]) Len() int {
+	return int(atomic.LoadInt64(&q.len))
+}
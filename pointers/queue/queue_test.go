@@ -0,0 +1,136 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package queue
+
+import (
+	"sync"
+	"testing"
+)
+
+type item struct {
+	producer int
+	seq      int
+}
+
+func TestQueue(t *testing.T) {
+	var (
+		q *Queue[int] = New[int]()
+	)
+	if _, ok := q.Dequeue(); ok {
+		t.Fatal("assertion failed, expected empty queue.")
+	}
+	if _, ok := q.TryDequeue(4); ok {
+		t.Fatal("assertion failed, expected empty queue.")
+	}
+	for i := 0; i < 16; i++ {
+		q.Enqueue(i)
+	}
+	if q.Len() != 16 {
+		t.Fatal("assertion failed, expected Len()==16. got", q.Len())
+	}
+	for i := 0; i < 16; i++ {
+		if v, ok := q.TryDequeue(0); !ok || v != i {
+			t.Fatalf("assertion failed, expected Dequeue()==%d. got %d, %t.", i, v, ok)
+		}
+	}
+	if q.Len() != 0 {
+		t.Fatal("assertion failed, expected Len()==0. got", q.Len())
+	}
+}
+
+func TestQueueConcurrent(t *testing.T) {
+	const (
+		nproducers = 8
+		nconsumers = 8
+		nitems     = 2000
+	)
+	var (
+		q        *Queue[item] = New[item]()
+		seen     [nproducers][nitems]int32
+		mu       sync.Mutex
+		pwg, cwg sync.WaitGroup
+		done     chan struct{} = make(chan struct{})
+	)
+	for p := 0; p < nproducers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < nitems; i++ {
+				q.Enqueue(item{p, i})
+			}
+		}(p)
+	}
+	for c := 0; c < nconsumers; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			var (
+				last [nproducers]int
+				got  []item
+			)
+			for i := range last {
+				last[i] = -1
+			}
+		LOOP:
+			for {
+				v, ok := q.TryDequeue(16)
+				if !ok {
+					select {
+					case <-done:
+						// producers are finished, drain.
+						if v, ok = q.Dequeue(); !ok {
+							break LOOP
+						}
+					default:
+						continue
+					}
+				}
+				// items of a single producer must be
+				// observed in FIFO order by any consumer.
+				if v.seq <= last[v.producer] {
+					t.Errorf("inconsistent state, producer(%d) order %d after %d.", v.producer, v.seq, last[v.producer])
+				}
+				last[v.producer] = v.seq
+				got = append(got, v)
+			}
+			mu.Lock()
+			for _, v := range got {
+				seen[v.producer][v.seq]++
+			}
+			mu.Unlock()
+		}()
+	}
+	pwg.Wait()
+	close(done)
+	cwg.Wait()
+	for p := range seen {
+		for i, n := range seen[p] {
+			if n != 1 {
+				t.Fatalf("inconsistent state, item(%d, %d) dequeued %d times.", p, i, n)
+			}
+		}
+	}
+	if q.Len() != 0 {
+		t.Fatal("assertion failed, expected Len()==0. got", q.Len())
+	}
+}
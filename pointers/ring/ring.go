@@ -0,0 +1,259 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package ring
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/mitghi/x/bit"
+)
+
+// - MARK: Ring-Buffer section.
+
+const (
+	// cacheline is the padding size used to keep
+	// producer and consumer indexes apart.
+	cacheline = 64
+	// spins is the number of yields before blocking
+	// variants start sleeping.
+	spins = 16
+	// maxbackoff is the upper bound of sleep between
+	// attempts in blocking variants.
+	maxbackoff = time.Millisecond
+	// maxcapacity is the upper bound of ring capacity,
+	// larger values are clamped. It keeps `Cap` within
+	// `int` on 32-bit platforms.
+	maxcapacity = 1 << 30
+)
+
+// Ring is a bounded lock-free MPMC ring buffer. Each slot
+// carries a sequence number which tells producers and
+// consumers whether the slot is ready for them. Items are
+// stored by value; a slot is only accessed by the producer
+// or consumer which owns its sequence number, so `Offer`
+// and `Poll` do not allocate.
+type Ring[T any] struct {
+	// Design: Bounded MPMC queue by Dmitry Vyukov;
+	//         1024cores.net.
+	_     [cacheline]byte
+	wri   uint64 // write index
+	_     [cacheline - 8]byte
+	rdi   uint64 // read index
+	_     [cacheline - 8]byte
+	mask  uint64   // size - 1
+	seqs  []uint64 // slot sequence numbers
+	nodes []T      // slots
+}
+
+// New allocates and initializes a new `Ring` and returns a
+// pointer to it. `capacity` is rounded up to the next power
+// of two with a minimum of 2 and a maximum of `1 << 30`.
+func New[T any](capacity uint64) *Ring[T] {
+	var (
+		size uint64   = roundCapacity(capacity)
+		r    *Ring[T] = &Ring[T]{
+			mask:  size - 1,
+			seqs:  make([]uint64, size),
+			nodes: make([]T, size),
+		}
+	)
+	for i := range r.seqs {
+		r.seqs[i] = uint64(i)
+	}
+	return r
+}
+
+// roundCapacity clamps `capacity` to [2, `maxcapacity`]
+// and rounds it up to the next power of two.
+func roundCapacity(capacity uint64) uint64 {
+	if capacity < 2 {
+		capacity = 2
+	} else if capacity > maxcapacity {
+		capacity = maxcapacity
+	}
+	return bit.RoundNextP2(capacity)
+}
+
+// Cap returns the capacity.
+func (r *Ring[T]) Cap() int {
+	return int(r.mask + 1)
+}
+
+// Len returns the number of items. Note, the
+// value is approximate under concurrent updates.
+func (r *Ring[T]) Len() int {
+	var (
+		rdi uint64 = atomic.LoadUint64(&r.rdi)
+		wri uint64 = atomic.LoadUint64(&r.wri)
+	)
+	if wri < rdi {
+		return 0
+	}
+	return int(wri - rdi)
+}
+
+// Offer inserts `v` and returns true. It does not
+// block and returns false when the ring is full.
+func (r *Ring[T]) Offer(v T) bool {
+	var (
+		pos uint64 = atomic.LoadUint64(&r.wri)
+		seq uint64
+		dif int64
+	)
+	for {
+		seq = atomic.LoadUint64(&r.seqs[pos&r.mask])
+		dif = int64(seq) - int64(pos)
+		if dif == 0 {
+			if atomic.CompareAndSwapUint64(&r.wri, pos, pos+1) {
+				break
+			}
+		} else if dif < 0 {
+			return false
+		} else {
+			pos = atomic.LoadUint64(&r.wri)
+		}
+	}
+	// slot is owned by this producer until its
+	// sequence number is published.
+	r.nodes[pos&r.mask] = v
+	atomic.StoreUint64(&r.seqs[pos&r.mask], pos+1)
+	return true
+}
+
+// Poll removes the oldest item and returns it along
+// with true. It does not block and returns false
+// when the ring is empty.
+func (r *Ring[T]) Poll() (value T, ok bool) {
+	var (
+		pos  uint64 = atomic.LoadUint64(&r.rdi)
+		seq  uint64
+		dif  int64
+		zero T
+	)
+	for {
+		seq = atomic.LoadUint64(&r.seqs[pos&r.mask])
+		dif = int64(seq) - int64(pos+1)
+		if dif == 0 {
+			if atomic.CompareAndSwapUint64(&r.rdi, pos, pos+1) {
+				break
+			}
+		} else if dif < 0 {
+			return value, false
+		} else {
+			pos = atomic.LoadUint64(&r.rdi)
+		}
+	}
+	// slot is owned by this consumer until its
+	// sequence number is published; it is cleared
+	// so the ring does not retain the item.
+	value = r.nodes[pos&r.mask]
+	r.nodes[pos&r.mask] = zero
+	atomic.StoreUint64(&r.seqs[pos&r.mask], pos+r.mask+1)
+	return value, true
+}
+
+// OfferN inserts items from `values` in order until the
+// ring is full and returns the number of inserted items.
+// Note, the batch is not inserted atomically as a whole.
+func (r *Ring[T]) OfferN(values []T) int {
+	for i := range values {
+		if !r.Offer(values[i]) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// PollN removes up to `len(dst)` items into `dst` until
+// the ring is empty and returns the number of removed
+// items. Note, the batch is not removed atomically as
+// a whole.
+func (r *Ring[T]) PollN(dst []T) int {
+	var (
+		ok bool
+	)
+	for i := range dst {
+		if dst[i], ok = r.Poll(); !ok {
+			return i
+		}
+	}
+	return len(dst)
+}
+
+// Put inserts `v` and blocks while the ring is full. It
+// returns `ctx.Err()` when `ctx` is done before insertion.
+func (r *Ring[T]) Put(ctx context.Context, v T) error {
+	return wait(ctx, func() bool { return r.Offer(v) })
+}
+
+// Take removes the oldest item and blocks while the
+// ring is empty. It returns `ctx.Err()` when `ctx` is
+// done before an item is available.
+func (r *Ring[T]) Take(ctx context.Context) (value T, err error) {
+	var (
+		ok bool
+	)
+	err = wait(ctx, func() bool {
+		value, ok = r.Poll()
+		return ok
+	})
+	return value, err
+}
+
+// wait retries `fn` with backoff until it succeeds
+// or `ctx` is done.
+func wait(ctx context.Context, fn func() bool) error {
+	var (
+		backoff time.Duration = time.Microsecond
+		timer   *time.Timer
+	)
+	for i := 0; ; i++ {
+		if fn() {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if i < spins {
+			runtime.Gosched()
+			continue
+		}
+		if timer == nil {
+			timer = time.NewTimer(backoff)
+			defer timer.Stop()
+		} else {
+			timer.Reset(backoff)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if backoff < maxbackoff {
+			backoff <<= 1
+		}
+	}
+}
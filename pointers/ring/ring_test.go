@@ -0,0 +1,212 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package ring
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRing(t *testing.T) {
+	var (
+		r *Ring[int] = New[int](5)
+	)
+	if r.Cap() != 8 {
+		t.Fatal("assertion failed, expected Cap()==8. got", r.Cap())
+	}
+	if _, ok := r.Poll(); ok {
+		t.Fatal("assertion failed, expected empty ring.")
+	}
+	for i := 0; i < 8; i++ {
+		if !r.Offer(i) {
+			t.Fatalf("inconsistent state, cannot offer %d.", i)
+		}
+	}
+	if r.Offer(8) {
+		t.Fatal("assertion failed, expected full ring.")
+	}
+	if r.Len() != 8 {
+		t.Fatal("assertion failed, expected Len()==8. got", r.Len())
+	}
+	for i := 0; i < 8; i++ {
+		if v, ok := r.Poll(); !ok || v != i {
+			t.Fatalf("assertion failed, expected Poll()==%d. got %d, %t.", i, v, ok)
+		}
+	}
+	if New[int](0).Cap() != 2 {
+		t.Fatal("assertion failed, expected minimum capacity 2.")
+	}
+}
+
+func TestRingBatch(t *testing.T) {
+	var (
+		r   *Ring[int] = New[int](4)
+		dst []int      = make([]int, 8)
+	)
+	if n := r.OfferN([]int{1, 2, 3, 4, 5, 6}); n != 4 {
+		t.Fatal("assertion failed, expected OfferN()==4. got", n)
+	}
+	if n := r.PollN(dst); n != 4 {
+		t.Fatal("assertion failed, expected PollN()==4. got", n)
+	}
+	for i := 0; i < 4; i++ {
+		if dst[i] != i+1 {
+			t.Fatalf("assertion failed, expected dst[%d]==%d. got %d.", i, i+1, dst[i])
+		}
+	}
+}
+
+func TestRingBlocking(t *testing.T) {
+	var (
+		r           *Ring[int] = New[int](2)
+		ctx, cancel            = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	)
+	defer cancel()
+	if _, err := r.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("assertion failed, expected DeadlineExceeded. got", err)
+	}
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		r.Offer(16)
+	}()
+	if v, err := r.Take(context.Background()); err != nil || v != 16 {
+		t.Fatal("assertion failed, expected Take()==16. got", v, err)
+	}
+	r.OfferN([]int{1, 2})
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := r.Put(ctx, 3); !errors.Is(err, context.Canceled) {
+		t.Fatal("assertion failed, expected Canceled. got", err)
+	}
+}
+
+func TestRingConcurrent(t *testing.T) {
+	const (
+		nproducers = 4
+		nconsumers = 4
+		nitems     = 2000
+	)
+	var (
+		r    *Ring[int] = New[int](64)
+		seen [nproducers * nitems]int32
+		mu   sync.Mutex
+		pwg  sync.WaitGroup
+		cwg  sync.WaitGroup
+	)
+	for p := 0; p < nproducers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < nitems; i++ {
+				if err := r.Put(context.Background(), p*nitems+i); err != nil {
+					t.Error("inconsistent state, Put failed.", err)
+					return
+				}
+			}
+		}(p)
+	}
+	for c := 0; c < nconsumers; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			var got []int
+			for i := 0; i < nitems; i++ {
+				v, err := r.Take(context.Background())
+				if err != nil {
+					t.Error("inconsistent state, Take failed.", err)
+					return
+				}
+				got = append(got, v)
+			}
+			mu.Lock()
+			for _, v := range got {
+				seen[v]++
+			}
+			mu.Unlock()
+		}()
+	}
+	pwg.Wait()
+	cwg.Wait()
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("inconsistent state, item %d taken %d times.", v, n)
+		}
+	}
+}
+
+func TestRingCapacity(t *testing.T) {
+	for _, c := range []uint64{maxcapacity - 1, maxcapacity, maxcapacity + 1, 1<<63 + 1, ^uint64(0)} {
+		if roundCapacity(c) != maxcapacity {
+			t.Fatal("assertion failed, expected roundCapacity(c)==maxcapacity. got", roundCapacity(c), c)
+		}
+	}
+	if roundCapacity(0) != 2 || roundCapacity(5) != 8 {
+		t.Fatal("assertion failed, expected roundCapacity within [2, maxcapacity].")
+	}
+}
+
+func TestRingAllocs(t *testing.T) {
+	var (
+		r *Ring[int] = New[int](8)
+	)
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Offer(1)
+		r.Poll()
+	})
+	if allocs != 0 {
+		t.Fatal("assertion failed, expected no allocations. got", allocs)
+	}
+}
+
+func BenchmarkRing(b *testing.B) {
+	var (
+		r *Ring[int] = New[int](1024)
+	)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if r.Offer(i) {
+				r.Poll()
+			}
+		}
+	})
+}
+
+func BenchmarkChannel(b *testing.B) {
+	var (
+		c chan int = make(chan int, 1024)
+	)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			select {
+			case c <- i:
+				<-c
+			default:
+			}
+		}
+	})
+}
@@ -0,0 +1,290 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package skiplist
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/mitghi/x/pointers"
+)
+
+// - MARK: Skiplist section.
+
+const (
+	// maxLevel is the maximum number of levels.
+	maxLevel = 24
+	// markTAG is the low-order tag marking a node
+	// as logically deleted at a given level.
+	markTAG uint = 0x1
+)
+
+// node is a skiplist item. `key`, `value` and `top`
+// are immutable; `next` pointers are tagged.
+type node[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	top   int              // highest level
+	next  []unsafe.Pointer // tagged *node[K, V] per level
+}
+
+// Map is a lock-free ordered map. A node is deleted
+// logically by marking its `next` pointers from top to
+// bottom; the mark on level 0 is the linearization point.
+// Marked nodes are unlinked by subsequent traversals. It
+// must be created with `New`.
+type Map[K cmp.Ordered, V any] struct {
+	// Design: The Art of Multiprocessor Programming
+	//         by Maurice Herlihy and Nir Shavit;
+	//         section 14.4, LockFreeSkipList.
+	head *node[K, V] // sentinel, smaller than any key
+	tail *node[K, V] // sentinel, larger than any key
+	len  int64
+}
+
+// New allocates and initializes a new
+// `Map` and returns a pointer to it.
+func New[K cmp.Ordered, V any]() *Map[K, V] {
+	var (
+		m *Map[K, V] = &Map[K, V]{
+			head: &node[K, V]{top: maxLevel - 1, next: make([]unsafe.Pointer, maxLevel)},
+			tail: &node[K, V]{top: maxLevel - 1, next: make([]unsafe.Pointer, maxLevel)},
+		}
+	)
+	for i := range m.head.next {
+		m.head.next[i] = unsafe.Pointer(m.tail)
+	}
+	return m
+}
+
+// Insert adds `key` with `value` to the map. It
+// returns false when `key` is already present.
+func (m *Map[K, V]) Insert(key K, value V) bool {
+	var (
+		preds, succs [maxLevel]*node[K, V]
+		top          int = level()
+		n            *node[K, V]
+		nxt          unsafe.Pointer
+	)
+	for {
+		if m.find(key, &preds, &succs) {
+			return false
+		}
+		n = &node[K, V]{key: key, value: value, top: top, next: make([]unsafe.Pointer, top+1)}
+		for l := 0; l <= top; l++ {
+			n.next[l] = unsafe.Pointer(succs[l])
+		}
+		if !atomic.CompareAndSwapPointer(&preds[0].next[0], unsafe.Pointer(succs[0]), unsafe.Pointer(n)) {
+			continue
+		}
+		atomic.AddInt64(&m.len, 1)
+		break
+	}
+	// `n` is present from here on; link upper
+	// levels unless it gets deleted meanwhile.
+	for l := 1; l <= top; l++ {
+		for {
+			nxt = atomic.LoadPointer(&n.next[l])
+			if pointers.HasTag(nxt) {
+				return true
+			}
+			if nxt != unsafe.Pointer(succs[l]) && !atomic.CompareAndSwapPointer(&n.next[l], nxt, unsafe.Pointer(succs[l])) {
+				continue
+			}
+			if atomic.CompareAndSwapPointer(&preds[l].next[l], unsafe.Pointer(succs[l]), unsafe.Pointer(n)) {
+				break
+			}
+			if !m.find(key, &preds, &succs) || succs[0] != n {
+				return true
+			}
+		}
+	}
+	return true
+}
+
+// Delete removes `key` from the map. It returns
+// false when `key` is not present.
+func (m *Map[K, V]) Delete(key K) bool {
+	var (
+		preds, succs [maxLevel]*node[K, V]
+		victim       *node[K, V]
+		succ         unsafe.Pointer
+	)
+	if !m.find(key, &preds, &succs) {
+		return false
+	}
+	victim = succs[0]
+	for l := victim.top; l > 0; l-- {
+		succ = atomic.LoadPointer(&victim.next[l])
+		for !pointers.HasTag(succ) {
+			atomic.CompareAndSwapPointer(&victim.next[l], succ, mark(succ))
+			succ = atomic.LoadPointer(&victim.next[l])
+		}
+	}
+	for {
+		succ = atomic.LoadPointer(&victim.next[0])
+		if pointers.HasTag(succ) {
+			// deleted by another goroutine.
+			return false
+		}
+		if atomic.CompareAndSwapPointer(&victim.next[0], succ, mark(succ)) {
+			atomic.AddInt64(&m.len, -1)
+			m.find(key, &preds, &succs)
+			return true
+		}
+	}
+}
+
+// Load returns the value stored for `key`
+// along with true when present. It does
+// not modify the map.
+func (m *Map[K, V]) Load(key K) (value V, ok bool) {
+	var (
+		curr *node[K, V] = m.seek(key)
+	)
+	if curr == m.tail || curr.key != key {
+		return value, false
+	}
+	return curr.value, true
+}
+
+// Contains returns whether `key` is present.
+func (m *Map[K, V]) Contains(key K) bool {
+	var (
+		curr *node[K, V] = m.seek(key)
+	)
+	return curr != m.tail && curr.key == key
+}
+
+// Len returns the number of keys. Note, the
+// value is approximate under concurrent updates.
+func (m *Map[K, V]) Len() int {
+	return int(atomic.LoadInt64(&m.len))
+}
+
+// Range calls `fn` sequentially for each key and
+// value in ascending key order until `fn` returns
+// false. Keys inserted or deleted concurrently may
+// or may not be visited.
+func (m *Map[K, V]) Range(fn func(key K, value V) bool) {
+	m.walk(next(m.head, 0), fn)
+}
+
+// RangeBetween is similar to `Range` but only visits
+// keys in range [`lo`, `hi`).
+func (m *Map[K, V]) RangeBetween(lo, hi K, fn func(key K, value V) bool) {
+	m.walk(m.seek(lo), func(key K, value V) bool {
+		return key < hi && fn(key, value)
+	})
+}
+
+// walk visits unmarked nodes on level 0
+// starting from `curr`.
+func (m *Map[K, V]) walk(curr *node[K, V], fn func(key K, value V) bool) {
+	var (
+		succ unsafe.Pointer
+	)
+	for curr != m.tail {
+		succ = atomic.LoadPointer(&curr.next[0])
+		if !pointers.HasTag(succ) && !fn(curr.key, curr.value) {
+			return
+		}
+		curr = (*node[K, V])(pointers.Untag(succ))
+	}
+}
+
+// find fills `preds` and `succs` with adjacent unmarked
+// nodes on each level such that `pred.key` < `key` <=
+// `succ.key` and returns whether `succs[0]` holds `key`.
+// Marked nodes found in between are unlinked.
+func (m *Map[K, V]) find(key K, preds, succs *[maxLevel]*node[K, V]) bool {
+	var (
+		pred, curr *node[K, V]
+		succ       unsafe.Pointer
+	)
+RETRY:
+	pred = m.head
+	for l := maxLevel - 1; l >= 0; l-- {
+		curr = next(pred, l)
+		for curr != m.tail {
+			succ = atomic.LoadPointer(&curr.next[l])
+			if pointers.HasTag(succ) {
+				if !atomic.CompareAndSwapPointer(&pred.next[l], unsafe.Pointer(curr), pointers.Untag(succ)) {
+					goto RETRY
+				}
+				curr = (*node[K, V])(pointers.Untag(succ))
+				continue
+			}
+			if curr.key >= key {
+				break
+			}
+			pred, curr = curr, (*node[K, V])(succ)
+		}
+		preds[l], succs[l] = pred, curr
+	}
+	return curr != m.tail && curr.key == key
+}
+
+// seek returns the first unmarked node on level 0 with
+// key >= `key` without unlinking marked nodes.
+func (m *Map[K, V]) seek(key K) *node[K, V] {
+	var (
+		pred, curr *node[K, V] = m.head, nil
+		succ       unsafe.Pointer
+	)
+	for l := maxLevel - 1; l >= 0; l-- {
+		curr = next(pred, l)
+		for curr != m.tail {
+			succ = atomic.LoadPointer(&curr.next[l])
+			if pointers.HasTag(succ) {
+				curr = (*node[K, V])(pointers.Untag(succ))
+				continue
+			}
+			if curr.key >= key {
+				break
+			}
+			pred, curr = curr, (*node[K, V])(succ)
+		}
+	}
+	return curr
+}
+
+// next returns the successor of `n` on level `l`.
+func next[K cmp.Ordered, V any](n *node[K, V], l int) *node[K, V] {
+	return (*node[K, V])(pointers.Untag(atomic.LoadPointer(&n.next[l])))
+}
+
+// mark returns `ptr` tagged as deleted.
+func mark(ptr unsafe.Pointer) unsafe.Pointer {
+	ptr, _ = pointers.TaggedPointer(ptr, markTAG)
+	return ptr
+}
+
+// level returns a random level in [0, maxLevel)
+// with geometric distribution.
+func level() int {
+	return bits.TrailingZeros64(rand.Uint64() | (1 << (maxLevel - 1)))
+}
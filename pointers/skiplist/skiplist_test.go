@@ -0,0 +1,122 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package skiplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	var (
+		m    *Map[int, string] = New[int, string]()
+		keys []int
+	)
+	for _, k := range []int{50, 10, 90, 30, 70} {
+		if !m.Insert(k, "v") {
+			t.Fatalf("inconsistent state, cannot insert %d.", k)
+		}
+	}
+	if m.Insert(30, "w") {
+		t.Fatal("assertion failed, expected duplicate insert to fail.")
+	}
+	if v, ok := m.Load(30); !ok || v != "v" {
+		t.Fatal("assertion failed, expected Load(30)==v. got", v, ok)
+	}
+	if m.Len() != 5 {
+		t.Fatal("assertion failed, expected Len()==5. got", m.Len())
+	}
+	if !m.Contains(70) || m.Contains(40) {
+		t.Fatal("assertion failed, unexpected Contains result.")
+	}
+	if !m.Delete(70) || m.Delete(70) || m.Contains(70) {
+		t.Fatal("inconsistent state, unexpected Delete result.")
+	}
+	m.Range(func(key int, value string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 4 || keys[0] != 10 || keys[1] != 30 || keys[2] != 50 || keys[3] != 90 {
+		t.Fatal("assertion failed, expected [10 30 50 90]. got", keys)
+	}
+	keys = keys[:0]
+	m.RangeBetween(20, 90, func(key int, value string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 2 || keys[0] != 30 || keys[1] != 50 {
+		t.Fatal("assertion failed, expected [30 50]. got", keys)
+	}
+}
+
+func TestMapConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nkeys    = 1024
+	)
+	var (
+		m        *Map[int, int] = New[int, int]()
+		inserted [nworkers]int
+		deleted  [nworkers]int
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < nkeys*4; i++ {
+				k := (i*13 + w) % nkeys
+				if i%3 == 0 {
+					if m.Delete(k) {
+						deleted[w]++
+					}
+				} else if m.Insert(k, k*2) {
+					inserted[w]++
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	var (
+		total int
+		last  int = -1
+		n     int
+	)
+	for w := range inserted {
+		total += inserted[w] - deleted[w]
+	}
+	m.Range(func(key int, value int) bool {
+		if key <= last || value != key*2 {
+			t.Fatalf("inconsistent state, invalid entry %d=%d after %d.", key, value, last)
+		}
+		if !m.Contains(key) {
+			t.Fatalf("inconsistent state, %d is not reachable.", key)
+		}
+		last = key
+		n++
+		return true
+	})
+	if n != total || m.Len() != total {
+		t.Fatalf("inconsistent state, expected %d keys. got %d (Len()==%d).", total, n, m.Len())
+	}
+}
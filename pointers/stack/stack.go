@@ -0,0 +1,134 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package stack
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/mitghi/x/pointers"
+)
+
+// - MARK: Treiber-Stack section.
+
+// node is a stack item. Nodes are immutable
+// once published.
+type node[T any] struct {
+	value T
+	next  unsafe.Pointer // *node[T], untagged
+}
+
+// Stack is a lock-free LIFO stack. `head` is a tagged
+// pointer to the top node where low-order bits hold a
+// version that is incremented on every mutation; an
+// empty stack has a nil head. Nodes are never reused,
+// therefore the garbage collector guarantees a node
+// address cannot re-appear while referenced; the
+// version adds protection against ABA.
+type Stack[T any] struct {
+	head unsafe.Pointer // tagged *node[T]
+	len  atomic.Int64   // 64-bit aligned on 32-bit platforms
+}
+
+// New allocates and initializes a new
+// `Stack` and returns a pointer to it.
+func New[T any]() *Stack[T] {
+	return &Stack[T]{}
+}
+
+// Push inserts `v` on top of the stack.
+func (s *Stack[T]) Push(v T) {
+	var (
+		n    *node[T] = &node[T]{value: v}
+		old  unsafe.Pointer
+		head unsafe.Pointer
+	)
+	for {
+		old = atomic.LoadPointer(&s.head)
+		n.next = pointers.Untag(old)
+		head = tagged(unsafe.Pointer(n), version(old))
+		if atomic.CompareAndSwapPointer(&s.head, old, head) {
+			s.len.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes the top item and returns it
+// along with true. It returns false when
+// the stack is empty.
+func (s *Stack[T]) Pop() (value T, ok bool) {
+	var (
+		top  *node[T]
+		old  unsafe.Pointer
+		head unsafe.Pointer
+	)
+	for {
+		old = atomic.LoadPointer(&s.head)
+		top = (*node[T])(pointers.Untag(old))
+		if top == nil {
+			return value, false
+		}
+		head = tagged(top.next, version(old))
+		if atomic.CompareAndSwapPointer(&s.head, old, head) {
+			s.len.Add(-1)
+			return top.value, true
+		}
+	}
+}
+
+// Peek returns the top item without removing it
+// along with true. It returns false when the stack
+// is empty.
+func (s *Stack[T]) Peek() (value T, ok bool) {
+	var (
+		top *node[T] = (*node[T])(pointers.Untag(atomic.LoadPointer(&s.head)))
+	)
+	if top == nil {
+		return value, false
+	}
+	return top.value, true
+}
+
+// Len returns the number of items. Note, the
+// value is approximate under concurrent updates.
+func (s *Stack[T]) Len() int {
+	return int(s.len.Load())
+}
+
+// tagged attaches `tag` to `ptr` unless it is nil. Note,
+// tagged nil pointers are invalid pointer values for the
+// runtime and must not be produced.
+func tagged(ptr unsafe.Pointer, tag uint) unsafe.Pointer {
+	if ptr == nil {
+		return nil
+	}
+	ptr, _ = pointers.TaggedPointer(ptr, tag)
+	return ptr
+}
+
+// version returns the successor of the version
+// attached to `head`.
+func version(head unsafe.Pointer) uint {
+	return (pointers.GetTag(head) + 1) & pointers.ArchMAXTAG
+}
@@ -0,0 +1,120 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package stack
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mitghi/x/structs"
+)
+
+func TestStack(t *testing.T) {
+	var (
+		s *Stack[int] = New[int]()
+	)
+	if _, ok := s.Pop(); ok {
+		t.Fatal("assertion failed, expected empty stack.")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Fatal("assertion failed, expected empty stack.")
+	}
+	for i := 0; i < 16; i++ {
+		s.Push(i)
+	}
+	if s.Len() != 16 {
+		t.Fatal("assertion failed, expected Len()==16. got", s.Len())
+	}
+	if v, ok := s.Peek(); !ok || v != 15 {
+		t.Fatal("assertion failed, expected Peek()==15. got", v, ok)
+	}
+	for i := 15; i >= 0; i-- {
+		if v, ok := s.Pop(); !ok || v != i {
+			t.Fatalf("assertion failed, expected Pop()==%d. got %d, %t.", i, v, ok)
+		}
+	}
+	if s.Len() != 0 {
+		t.Fatal("assertion failed, expected Len()==0. got", s.Len())
+	}
+}
+
+func TestStackConcurrent(t *testing.T) {
+	const (
+		nworkers = 8
+		nitems   = 2000
+	)
+	var (
+		s    *Stack[int] = New[int]()
+		seen [nworkers * nitems]int32
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			var popped []int
+			for i := 0; i < nitems; i++ {
+				s.Push(w*nitems + i)
+				if i%2 == 1 {
+					if v, ok := s.Pop(); ok {
+						popped = append(popped, v)
+					}
+				}
+			}
+			mu.Lock()
+			for _, v := range popped {
+				seen[v]++
+			}
+			mu.Unlock()
+		}(w)
+	}
+	wg.Wait()
+	for {
+		v, ok := s.Pop()
+		if !ok {
+			break
+		}
+		seen[v]++
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("inconsistent state, item %d popped %d times.", v, n)
+		}
+	}
+	if s.Len() != 0 {
+		t.Fatal("assertion failed, expected Len()==0. got", s.Len())
+	}
+}
+
+func TestStackAlign(t *testing.T) {
+	for _, arch := range []string{"386", "arm", "mips"} {
+		l, err := structs.LayoutFor(Stack[int]{}, arch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(l.Unaligned64) != 0 {
+			t.Fatalf("assertion failed, expected aligned 64-bit fields on %s. got %v.", arch, l.Unaligned64)
+		}
+	}
+}
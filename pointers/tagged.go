@@ -43,14 +43,19 @@ func TaggedPointer(ptr unsafe.Pointer, tag uint) (unsafe.Pointer, error) {
 		// flip the table, not this time!
 		panic(EPTRINVALT)
 	}
-	return unsafe.Pointer(uintptr(ptr) | uintptr(tag)), nil
+	// note, tag is added rather than or'ed so that
+	// checkptr can track the original pointer.
+	return unsafe.Pointer(uintptr(ptr) + (uintptr(tag) &^ (uintptr(ptr) & uintptr(ArchMAXTAG)))), nil
 }
 
 // Untag is a function for untagging pointers. It
 // returns a `unsafe.Pointer` with low-order bits
 // set to 0.
 func Untag(ptr unsafe.Pointer) unsafe.Pointer {
-	return unsafe.Pointer(uintptr(ptr) & ArchPTRMASK)
+	// note, tag bits are cleared with &^ rather than
+	// masked with & ArchPTRMASK, checkptr only tracks
+	// the original pointer through +, - and &^.
+	return unsafe.Pointer(uintptr(ptr) &^ uintptr(ArchMAXTAG))
 }
 
 // HasTag returns whether the given pointer `ptr`
@@ -53,3 +53,26 @@ func TestTag(t *testing.T) {
 		t.Fatal("assertion failed, expected tag==0.")
 	}
 }
+
+func TestUntagAllTags(t *testing.T) {
+	const sval = 8
+	var (
+		s    *Sample        = &Sample{value: sval}
+		base unsafe.Pointer = unsafe.Pointer(s)
+	)
+	for tag := uint(0); tag <= ArchMAXTAG; tag++ {
+		sptr, err := TaggedPointer(base, tag)
+		if err != nil {
+			t.Fatal("assertion failed, expected==nil.")
+		}
+		if GetTag(sptr) != tag {
+			t.Fatal("assertion failed, expected GetTag(sptr)==tag.", tag)
+		}
+		if Untag(sptr) != base {
+			t.Fatal("assertion failed, expected Untag(sptr)==base.", tag)
+		}
+		if (*Sample)(Untag(sptr)).value != sval {
+			t.Fatal("inconsistent state.")
+		}
+	}
+}
@@ -0,0 +1,260 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+)
+
+// - MARK: Architecture section.
+
+// LayoutFor computes the layout of struct `v` for the
+// gc compiler targeting GOARCH `arch`, following the
+// rules of `go/types.SizesFor`. The `sync/atomic` types
+// keep their 64-bit alignment on 32-bit architectures.
+// It returns EARCH for unknown architectures and
+// ENOTSTRUCT on non-structs.
+func LayoutFor(v interface{}, arch string) (Layout, error) {
+	var (
+		sizes types.Sizes = types.SizesFor("gc", arch)
+	)
+	if sizes == nil {
+		return Layout{}, EARCH
+	}
+	stype, err := structType(v)
+	if err != nil {
+		return Layout{}, err
+	}
+	var (
+		st      types.Type    = toTypes(stype)
+		s       *types.Struct = st.Underlying().(*types.Struct)
+		vars    []*types.Var  = make([]*types.Var, s.NumFields())
+		fields  []Field       = make([]Field, s.NumFields())
+		offsets []int64
+	)
+	for i := range vars {
+		vars[i] = s.Field(i)
+	}
+	offsets = sizes.Offsetsof(vars)
+	for i, fv := range vars {
+		var (
+			field reflect.StructField = stype.Field(i)
+		)
+		fields[i] = Field{
+			Name:   field.Name,
+			Type:   field.Type.String(),
+			Offset: uintptr(offsets[i]),
+			Size:   uintptr(sizes.Sizeof(fv.Type())),
+			Align:  uintptr(sizes.Alignof(fv.Type())),
+			Tag:    string(field.Tag),
+		}
+	}
+	return newLayout(stype, arch, uintptr(sizes.Sizeof(st)), uintptr(sizes.Alignof(st)), fields, archSizer{sizes}), nil
+}
+
+// archSizer is the `sizer` of a `types.Sizes`.
+type archSizer struct {
+	sizes types.Sizes
+}
+
+func (a archSizer) offsetsof(t reflect.Type) []uintptr {
+	var (
+		s       *types.Struct = toTypes(t).Underlying().(*types.Struct)
+		vars    []*types.Var  = make([]*types.Var, s.NumFields())
+		offsets []uintptr     = make([]uintptr, s.NumFields())
+	)
+	for i := range vars {
+		vars[i] = s.Field(i)
+	}
+	for i, off := range a.sizes.Offsetsof(vars) {
+		offsets[i] = uintptr(off)
+	}
+	return offsets
+}
+
+func (a archSizer) sizeof(t reflect.Type) uintptr {
+	return uintptr(a.sizes.Sizeof(toTypes(t)))
+}
+
+// toTypes converts `t` to an equivalent `types.Type` as
+// far as sizes and alignments are concerned. Pointer-like
+// types do not retain their element type.
+func toTypes(t reflect.Type) types.Type {
+	switch t.Kind() {
+	case reflect.Bool:
+		return types.Typ[types.Bool]
+	case reflect.Int:
+		return types.Typ[types.Int]
+	case reflect.Int8:
+		return types.Typ[types.Int8]
+	case reflect.Int16:
+		return types.Typ[types.Int16]
+	case reflect.Int32:
+		return types.Typ[types.Int32]
+	case reflect.Int64:
+		return types.Typ[types.Int64]
+	case reflect.Uint:
+		return types.Typ[types.Uint]
+	case reflect.Uint8:
+		return types.Typ[types.Uint8]
+	case reflect.Uint16:
+		return types.Typ[types.Uint16]
+	case reflect.Uint32:
+		return types.Typ[types.Uint32]
+	case reflect.Uint64:
+		return types.Typ[types.Uint64]
+	case reflect.Uintptr:
+		return types.Typ[types.Uintptr]
+	case reflect.Float32:
+		return types.Typ[types.Float32]
+	case reflect.Float64:
+		return types.Typ[types.Float64]
+	case reflect.Complex64:
+		return types.Typ[types.Complex64]
+	case reflect.Complex128:
+		return types.Typ[types.Complex128]
+	case reflect.String:
+		return types.Typ[types.String]
+	case reflect.UnsafePointer:
+		return types.Typ[types.UnsafePointer]
+	case reflect.Slice:
+		return types.NewSlice(types.Typ[types.Uint8])
+	case reflect.Interface:
+		return types.NewInterfaceType(nil, nil)
+	case reflect.Array:
+		return types.NewArray(toTypes(t.Elem()), int64(t.Len()))
+	case reflect.Struct:
+		var (
+			fields []*types.Var = make([]*types.Var, t.NumField())
+		)
+		for i := range fields {
+			fields[i] = types.NewField(token.NoPos, nil, t.Field(i).Name, toTypes(t.Field(i).Type), false)
+		}
+		var (
+			s types.Type = types.NewStruct(fields, nil)
+		)
+		if t.PkgPath() == "sync/atomic" && t.Name() == "align64" {
+			// the compiler aligns structs holding this
+			// marker to 8 bytes on every architecture.
+			obj := types.NewTypeName(token.NoPos, types.NewPackage(t.PkgPath(), "atomic"), t.Name(), nil)
+			return types.NewNamed(obj, s, nil)
+		}
+		return s
+	}
+	// pointers, maps, channels and functions
+	// are a single pointer word.
+	return types.NewPointer(types.Typ[types.Uint8])
+}
+
+// FieldDiff reports a field placed differently
+// on two architectures.
+type FieldDiff struct {
+	Name   string
+	Offset [2]uintptr
+	Size   [2]uintptr
+	Align  [2]uintptr
+}
+
+// LayoutDiff compares layouts of a struct
+// on two architectures.
+type LayoutDiff struct {
+	Name   string
+	Arch   [2]string
+	Size   [2]uintptr
+	Align  [2]uintptr
+	Fields []FieldDiff // fields whose placement differs
+	// Unaligned64 lists, per architecture, the 64-bit
+	// integer words not 8-byte aligned.
+	Unaligned64 [2][]string
+}
+
+// Equal returns whether both layouts are identical.
+func (d LayoutDiff) Equal() bool {
+	return d.Size[0] == d.Size[1] && d.Align[0] == d.Align[1] && len(d.Fields) == 0
+}
+
+// Diff compares layouts `a` and `b` of the same struct.
+func Diff(a, b Layout) LayoutDiff {
+	var (
+		d LayoutDiff = LayoutDiff{
+			Name:        a.Name,
+			Arch:        [2]string{a.Arch, b.Arch},
+			Size:        [2]uintptr{a.Size, b.Size},
+			Align:       [2]uintptr{a.Align, b.Align},
+			Unaligned64: [2][]string{a.Unaligned64, b.Unaligned64},
+		}
+	)
+	for i := 0; i < len(a.Fields) && i < len(b.Fields); i++ {
+		var (
+			fa, fb Field = a.Fields[i], b.Fields[i]
+		)
+		if fa.Offset != fb.Offset || fa.Size != fb.Size || fa.Align != fb.Align {
+			d.Fields = append(d.Fields, FieldDiff{
+				Name:   fa.Name,
+				Offset: [2]uintptr{fa.Offset, fb.Offset},
+				Size:   [2]uintptr{fa.Size, fb.Size},
+				Align:  [2]uintptr{fa.Align, fb.Align},
+			})
+		}
+	}
+	return d
+}
+
+// DiffArch compares the layouts of struct `v` on
+// architectures `a` and `b`.
+func DiffArch(v interface{}, a, b string) (LayoutDiff, error) {
+	la, err := LayoutFor(v, a)
+	if err != nil {
+		return LayoutDiff{}, err
+	}
+	lb, err := LayoutFor(v, b)
+	if err != nil {
+		return LayoutDiff{}, err
+	}
+	return Diff(la, lb), nil
+}
+
+// String returns the differences as a table.
+func (d LayoutDiff) String() string {
+	const (
+		_fmtheader = "[struct: %-16s %s/%s size=%d/%d align=%d/%d ]\n"
+		_fmtbody   = "[  %-22s offset=%d/%d size=%d/%d align=%d/%d ]\n"
+	)
+	var (
+		buff bytes.Buffer
+	)
+	fmt.Fprintf(&buff, _fmtheader, d.Name, d.Arch[0], d.Arch[1], d.Size[0], d.Size[1], d.Align[0], d.Align[1])
+	for _, f := range d.Fields {
+		fmt.Fprintf(&buff, _fmtbody, f.Name, f.Offset[0], f.Offset[1], f.Size[0], f.Size[1], f.Align[0], f.Align[1])
+	}
+	for i, names := range d.Unaligned64 {
+		for _, name := range names {
+			fmt.Fprintf(&buff, "[  unaligned 64-bit field on %s: %s ]\n", d.Arch[i], name)
+		}
+	}
+	return buff.String()
+}
@@ -0,0 +1,151 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+type stats struct {
+	Ready   bool
+	Count   int64
+	Atomic  atomic.Int64
+	Elapsed time.Duration
+}
+
+type mixed struct {
+	S  string
+	B  []byte
+	I  interface{}
+	M  map[string]int
+	F  func()
+	C  chan int
+	P  unsafe.Pointer
+	N  int
+	A  [3]inner
+	Z  complex128
+	T  struct{}
+	U8 uint8
+}
+
+func TestLayoutForHost(t *testing.T) {
+	for _, v := range []interface{}{padded{}, trailing{}, outer{}, counters{}, stats{}, mixed{}} {
+		host, _ := Analyze(v)
+		l, err := LayoutFor(v, runtime.GOARCH)
+		if err != nil || !reflect.DeepEqual(host, l) {
+			t.Fatalf("expected host layout %+v; got %+v ( %v ).", host, l, err)
+		}
+	}
+}
+
+func TestLayoutFor386(t *testing.T) {
+	l, err := LayoutFor(&stats{}, "386")
+	if err != nil || l.Arch != "386" {
+		t.Fatal(err)
+	}
+	// int64 is 4-byte aligned on 386, atomic.Int64 is not.
+	for i, want := range []uintptr{0, 4, 16, 24} {
+		if l.Fields[i].Offset != want {
+			t.Errorf("expected %s at %d; got %d.", l.Fields[i].Name, want, l.Fields[i].Offset)
+		}
+	}
+	if l.Size != 32 || l.Align != 8 || !slices.Equal(l.Unaligned64, []string{"Count"}) {
+		t.Fatalf("inconsistent state %+v.", l)
+	}
+	if !strings.Contains(l.String(), "[  unaligned 64-bit field on 386: Count ]") {
+		t.Fatalf("assertion failed, unexpected table:\n%s", l)
+	}
+	if m, _ := LayoutFor(mixed{}, "arm"); m.Size != 92 {
+		t.Fatalf("unexpected arm size %d.", m.Size)
+	}
+	if _, err = LayoutFor(stats{}, "pdp11"); err != EARCH {
+		t.Fatalf("expected EARCH; got %v.", err)
+	}
+	if _, err = LayoutFor(1, "386"); err != ENOTSTRUCT {
+		t.Fatalf("expected ENOTSTRUCT; got %v.", err)
+	}
+}
+
+type point struct {
+	X int32
+	Y int64
+}
+
+type nested struct {
+	A int32
+	P point
+	E [3]point
+	W [2]uint64
+	B [1 << 16]byte
+}
+
+func TestLayoutForNested(t *testing.T) {
+	for _, arch := range []string{"386", "arm"} {
+		l, err := LayoutFor(nested{}, arch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// point is 12 bytes, 4-byte aligned: P.Y at 8,
+		// E at 16 with Y at 20, 32 and 44, W at 52.
+		if want := []string{"E[0].Y", "E[2].Y", "W[*]"}; !slices.Equal(l.Unaligned64, want) {
+			t.Fatalf("expected %v on %s; got %v.", want, arch, l.Unaligned64)
+		}
+		if strings.Count(l.String(), "unaligned 64-bit field on "+arch) != 3 || !strings.Contains(l.String(), arch+": E[2].Y ]") {
+			t.Fatalf("assertion failed, unexpected table:\n%s", l)
+		}
+	}
+	if l, _ := LayoutFor(nested{}, "amd64"); len(l.Unaligned64) != 0 {
+		t.Fatalf("expected no hazards on amd64; got %v.", l.Unaligned64)
+	}
+	host, _ := Analyze(nested{})
+	l, _ := LayoutFor(nested{}, runtime.GOARCH)
+	if !slices.Equal(host.Unaligned64, l.Unaligned64) {
+		t.Fatalf("inconsistent state, host %v; computed %v.", host.Unaligned64, l.Unaligned64)
+	}
+}
+
+func TestDiffArch(t *testing.T) {
+	d, err := DiffArch(stats{}, "amd64", "arm")
+	if err != nil || d.Equal() || d.Size != [2]uintptr{32, 32} || len(d.Fields) != 2 {
+		t.Fatalf("inconsistent state %+v ( %v ).", d, err)
+	}
+	if d.Fields[0].Name != "Count" || d.Fields[0].Offset != [2]uintptr{8, 4} || d.Fields[0].Align != [2]uintptr{8, 4} {
+		t.Fatalf("unexpected field diff %+v.", d.Fields[0])
+	}
+	if len(d.Unaligned64[0]) != 0 || !slices.Equal(d.Unaligned64[1], []string{"Count"}) {
+		t.Fatalf("unexpected hazards %v.", d.Unaligned64)
+	}
+	if !strings.Contains(d.String(), "unaligned 64-bit field on arm: Count") {
+		t.Fatalf("assertion failed, unexpected report:\n%s", d)
+	}
+	if d, _ = DiffArch(padded{}, "amd64", "wasm"); !d.Equal() {
+		t.Fatalf("expected identical layouts; got %+v.", d)
+	}
+}
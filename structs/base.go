@@ -22,3 +22,34 @@
 
 // Package structs provides utilities for debugging and working with structs.
 package structs
+
+import (
+	"errors"
+	"reflect"
+)
+
+var (
+	ENOTSTRUCT error = errors.New("structs: not a struct.")
+	ELINESIZE  error = errors.New("structs: line size is not a power of two.")
+	EARCH      error = errors.New("structs: unknown architecture.")
+)
+
+// structType returns the struct type of `v`. `v` may
+// be a struct value, a (multi-level) pointer to a struct
+// or a `reflect.Type` of those.
+func structType(v interface{}) (reflect.Type, error) {
+	var (
+		stype reflect.Type
+		ok    bool
+	)
+	if stype, ok = v.(reflect.Type); !ok {
+		stype = reflect.TypeOf(v)
+	}
+	for stype != nil && stype.Kind() == reflect.Ptr {
+		stype = stype.Elem()
+	}
+	if stype == nil || stype.Kind() != reflect.Struct {
+		return nil, ENOTSTRUCT
+	}
+	return stype, nil
+}
@@ -0,0 +1,206 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// - MARK: Cache-Line section.
+
+const (
+	// DefaultLineSize is the cache line size used
+	// when none is given.
+	DefaultLineSize uintptr = 64
+	// hotTAG is the struct tag key marking fields
+	// written independently, e.g. `x:"hot"`.
+	hotTAG string = "x"
+)
+
+// LineField describes the cache lines
+// occupied by a field.
+type LineField struct {
+	Name      string
+	Offset    uintptr
+	Size      uintptr
+	FirstLine int
+	LastLine  int
+	Straddles bool // spans more than one line
+	Hot       bool // tagged `x:"hot"`
+}
+
+// Conflict reports two hot fields sharing a line.
+type Conflict struct {
+	A, B string
+	Line int
+}
+
+// Padding suggests inserting `Bytes` bytes of padding
+// ( e.g. `_ [Bytes]byte` ) right before field `Before`.
+type Padding struct {
+	Before string
+	Bytes  uintptr
+}
+
+// CacheReport describes how the fields of a struct
+// map onto cache lines.
+type CacheReport struct {
+	Name        string
+	LineSize    uintptr
+	Size        uintptr
+	Fields      []LineField
+	Lines       [][]string // field names per line
+	Straddling  []string   // fields spanning a line boundary
+	Conflicts   []Conflict // hot fields sharing a line
+	Suggestions []Padding  // padding separating hot fields
+	PaddedSize  uintptr    // size once suggestions are applied
+}
+
+// isHot returns whether struct tag `tag`
+// marks a field as hot.
+func isHot(tag string) bool {
+	for _, v := range strings.Split(reflect.StructTag(tag).Get(hotTAG), ",") {
+		if strings.TrimSpace(v) == "hot" {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheLines maps the fields of struct `v` onto cache
+// lines of `lineSize` bytes ( DefaultLineSize when 0 ).
+// It reports fields straddling a line boundary, hot
+// fields ( tagged `x:"hot"` ) sharing a line, and the
+// padding moving each such hot field to a new line.
+// It assumes the struct itself is allocated on a line
+// boundary. It returns ELINESIZE when `lineSize` is
+// not a power of two and ENOTSTRUCT on non-structs.
+func CacheLines(v interface{}, lineSize uintptr) (CacheReport, error) {
+	if lineSize == 0 {
+		lineSize = DefaultLineSize
+	}
+	if lineSize&(lineSize-1) != 0 {
+		return CacheReport{}, ELINESIZE
+	}
+	l, err := Analyze(v)
+	if err != nil {
+		return CacheReport{}, err
+	}
+	var (
+		r CacheReport = CacheReport{
+			Name:     l.Name,
+			LineSize: lineSize,
+			Size:     l.Size,
+			Fields:   make([]LineField, len(l.Fields)),
+			Lines:    make([][]string, (l.Size+lineSize-1)/lineSize),
+		}
+		hot []LineField
+	)
+	for i, f := range l.Fields {
+		lf := LineField{
+			Name:      f.Name,
+			Offset:    f.Offset,
+			Size:      f.Size,
+			FirstLine: int(f.Offset / lineSize),
+			LastLine:  int((f.Offset + max(f.Size, 1) - 1) / lineSize),
+			Hot:       isHot(f.Tag),
+		}
+		lf.Straddles = f.Size > 0 && lf.FirstLine != lf.LastLine
+		if lf.Straddles {
+			r.Straddling = append(r.Straddling, f.Name)
+		}
+		if f.Size > 0 {
+			for line := lf.FirstLine; line <= lf.LastLine; line++ {
+				r.Lines[line] = append(r.Lines[line], f.Name)
+			}
+		}
+		if lf.Hot {
+			for _, h := range hot {
+				if h.Size > 0 && f.Size > 0 && h.LastLine >= lf.FirstLine {
+					r.Conflicts = append(r.Conflicts, Conflict{A: h.Name, B: f.Name, Line: lf.FirstLine})
+				}
+			}
+			hot = append(hot, lf)
+		}
+		r.Fields[i] = lf
+	}
+	r.Suggestions, r.PaddedSize = isolate(l, lineSize)
+	return r, nil
+}
+
+// isolate lays out the fields of `l` again, inserting
+// padding so that no hot field starts on the line where
+// the preceding hot field ends. It returns the insertions
+// and the resulting size.
+func isolate(l Layout, lineSize uintptr) ([]Padding, uintptr) {
+	var (
+		pads   []Padding
+		offset uintptr
+		start  uintptr
+		hotEnd uintptr // end of the last hot field, 0 if none
+	)
+	for _, f := range l.Fields {
+		var (
+			hot bool = isHot(f.Tag) && f.Size > 0
+		)
+		start = alignUp(offset, f.Align)
+		if hot && hotEnd > 0 && start/lineSize <= (hotEnd-1)/lineSize {
+			target := alignUp(start, lineSize)
+			pads = append(pads, Padding{Before: f.Name, Bytes: target - offset})
+			start = target
+		}
+		if hot {
+			hotEnd = start + f.Size
+		}
+		offset = start + f.Size
+	}
+	return pads, alignUp(offset, max(l.Align, 1))
+}
+
+// String returns the report as a table.
+func (r CacheReport) String() string {
+	const (
+		_fmtheader = "[struct: %-16s size=%-6d line=%-4d lines=%-4d padded=%-6d]\n"
+		_fmtline   = "[  line %-4d %s ]\n"
+	)
+	var (
+		buff bytes.Buffer
+	)
+	fmt.Fprintf(&buff, _fmtheader, r.Name, r.Size, r.LineSize, len(r.Lines), r.PaddedSize)
+	for i, names := range r.Lines {
+		fmt.Fprintf(&buff, _fmtline, i, strings.Join(names, ", "))
+	}
+	for _, name := range r.Straddling {
+		fmt.Fprintf(&buff, "[  straddles: %s ]\n", name)
+	}
+	for _, c := range r.Conflicts {
+		fmt.Fprintf(&buff, "[  false sharing: %s and %s on line %d ]\n", c.A, c.B, c.Line)
+	}
+	for _, p := range r.Suggestions {
+		fmt.Fprintf(&buff, "[  pad %d bytes before %s ]\n", p.Bytes, p.Before)
+	}
+	return buff.String()
+}
@@ -0,0 +1,81 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type counters struct {
+	Reads  uint64 `x:"hot"`
+	Writes uint64 `x:"hot"`
+	Name   [52]byte
+	Misses uint64 `json:"misses" x:"cold,hot"`
+	Flag   bool
+}
+
+func TestCacheLines(t *testing.T) {
+	r, err := CacheLines(&counters{}, 0)
+	if err != nil || r.LineSize != 64 || r.Size != 88 || len(r.Lines) != 2 {
+		t.Fatalf("inconsistent state %+v ( %v ).", r, err)
+	}
+	if len(r.Straddling) != 1 || r.Straddling[0] != "Name" {
+		t.Fatalf("expected Name straddling; got %v.", r.Straddling)
+	}
+	if got := strings.Join(r.Lines[1], ","); got != "Name,Misses,Flag" {
+		t.Fatalf("expected line 1 to hold Name,Misses,Flag; got %s.", got)
+	}
+	if len(r.Conflicts) != 1 || r.Conflicts[0] != (Conflict{A: "Reads", B: "Writes", Line: 0}) {
+		t.Fatalf("expected Reads/Writes conflict; got %v.", r.Conflicts)
+	}
+	if !r.Fields[3].Hot || r.Fields[2].Hot {
+		t.Fatal("assertion failed, unexpected hot fields.")
+	}
+	// Writes moves to line 1, which pushes Misses to line 2.
+	if len(r.Suggestions) != 1 || r.Suggestions[0] != (Padding{Before: "Writes", Bytes: 56}) || r.PaddedSize != 144 {
+		t.Fatalf("expected 56 bytes before Writes and padded size 144; got %v, %d.", r.Suggestions, r.PaddedSize)
+	}
+	// applying the suggestions must separate hot fields.
+	padded := reflect.StructOf([]reflect.StructField{
+		{Name: "Reads", Type: reflect.TypeOf(uint64(0)), Tag: `x:"hot"`},
+		{Name: "P0", Type: reflect.TypeOf([56]byte{})},
+		{Name: "Writes", Type: reflect.TypeOf(uint64(0)), Tag: `x:"hot"`},
+		{Name: "Name", Type: reflect.TypeOf([52]byte{})},
+		{Name: "Misses", Type: reflect.TypeOf(uint64(0)), Tag: `x:"hot"`},
+		{Name: "Flag", Type: reflect.TypeOf(false)},
+	})
+	if r, _ = CacheLines(padded, 64); len(r.Conflicts) != 0 || len(r.Suggestions) != 0 || r.Size != 144 {
+		t.Fatalf("assertion failed, unexpected padded report %+v.", r)
+	}
+	if !strings.Contains(r.String(), "line 2") {
+		t.Fatal("assertion failed, expected line rows.")
+	}
+	if _, err = CacheLines(counters{}, 48); err != ELINESIZE {
+		t.Fatalf("expected ELINESIZE; got %v.", err)
+	}
+	if _, err = CacheLines("", 64); err != ENOTSTRUCT {
+		t.Fatalf("expected ENOTSTRUCT; got %v.", err)
+	}
+}
@@ -0,0 +1,308 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// - MARK: Formatter section.
+
+// Formatter renders a `Layout` to `w`.
+type Formatter interface {
+	Format(w io.Writer, l Layout) error
+}
+
+// FormatterFunc adapts a function to `Formatter`.
+type FormatterFunc func(w io.Writer, l Layout) error
+
+// Format calls `fn`.
+func (fn FormatterFunc) Format(w io.Writer, l Layout) error {
+	return fn(w, l)
+}
+
+// Render analyzes struct `v` and renders its layout
+// with `f`. It returns ENOTSTRUCT on non-structs.
+func Render(w io.Writer, v interface{}, f Formatter) error {
+	l, err := Analyze(v)
+	if err != nil {
+		return err
+	}
+	return f.Format(w, l)
+}
+
+// TextFormatter renders the fixed-width table
+// of `Layout.String`.
+type TextFormatter struct{}
+
+// Format implements `Formatter`.
+func (TextFormatter) Format(w io.Writer, l Layout) error {
+	_, err := io.WriteString(w, l.String())
+	return err
+}
+
+// JSONFormatter renders the layout as JSON,
+// indented by `Indent` when not empty.
+type JSONFormatter struct {
+	Indent string
+}
+
+// Format implements `Formatter`.
+func (f JSONFormatter) Format(w io.Writer, l Layout) error {
+	var (
+		enc *json.Encoder = json.NewEncoder(w)
+	)
+	enc.SetIndent("", f.Indent)
+	return enc.Encode(l)
+}
+
+// CSVFormatter renders one record per field
+// preceded by a header record.
+type CSVFormatter struct{}
+
+// Format implements `Formatter`.
+func (CSVFormatter) Format(w io.Writer, l Layout) error {
+	var (
+		cw *csv.Writer          = csv.NewWriter(w)
+		u  func(uintptr) string = func(v uintptr) string { return strconv.FormatUint(uint64(v), 10) }
+	)
+	cw.Write([]string{"struct", "field", "type", "offset", "size", "align", "pad_before", "pad_after"})
+	for _, f := range l.Fields {
+		cw.Write([]string{l.Name, f.Name, f.Type, u(f.Offset), u(f.Size), u(f.Align), u(f.PadBefore), u(f.PadAfter)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// MarkdownFormatter renders a Markdown table
+// followed by a summary line.
+type MarkdownFormatter struct{}
+
+// Format implements `Formatter`.
+func (MarkdownFormatter) Format(w io.Writer, l Layout) error {
+	var (
+		sb  strings.Builder
+		esc *strings.Replacer = strings.NewReplacer("|", `\|`, "\n", " ")
+	)
+	fmt.Fprintf(&sb, "### `%s`\n\n", esc.Replace(l.Name))
+	sb.WriteString("| Field | Type | Offset | Size | Align | Padding before | Padding after |\n")
+	sb.WriteString("|---|---|--:|--:|--:|--:|--:|\n")
+	for _, f := range l.Fields {
+		fmt.Fprintf(&sb, "| %s | `%s` | %d | %d | %d | %d | %d |\n",
+			esc.Replace(f.Name), esc.Replace(f.Type), f.Offset, f.Size, f.Align, f.PadBefore, f.PadAfter)
+	}
+	fmt.Fprintf(&sb, "\nSize **%d** bytes, align %d, padding %d bytes; optimal order `%s` is %d bytes.\n",
+		l.Size, l.Align, l.Waste, esc.Replace(strings.Join(l.Optimal, ", ")), l.OptimalSize)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// - MARK: Byte-Map section.
+
+// palette colors fields of byte maps; padding is gray.
+var (
+	palette []string = []string{
+		"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3", "#fdb462",
+		"#b3de69", "#fccde5", "#bc80bd", "#ccebc5", "#ffed6f", "#a6cee3",
+	}
+	padcolor string = "#d9d9d9"
+)
+
+// byteSpan is a run of bytes owned by the same
+// field, `owner` is -1 for padding.
+type byteSpan struct {
+	offset, size int
+	owner        int
+}
+
+// byteRow is a row of `cols` bytes; `count` is the
+// number of identical rows it stands for.
+type byteRow struct {
+	offset int
+	count  int
+	spans  []byteSpan
+}
+
+// byteSpans returns the runs of field and padding
+// bytes of the layout in offset order.
+func byteSpans(l Layout) []byteSpan {
+	var (
+		spans []byteSpan
+		off   int
+	)
+	for i, f := range l.Fields {
+		if f.Size == 0 {
+			continue
+		}
+		if int(f.Offset) > off {
+			spans = append(spans, byteSpan{off, int(f.Offset) - off, -1})
+		}
+		spans = append(spans, byteSpan{int(f.Offset), int(f.Size), i})
+		off = int(f.Offset + f.Size)
+	}
+	if int(l.Size) > off {
+		spans = append(spans, byteSpan{off, int(l.Size) - off, -1})
+	}
+	return spans
+}
+
+// byteRows splits the layout into rows of `cols`
+// bytes. Consecutive rows filled by a single span
+// are collapsed into one row, hence the number of
+// rows is bounded by the number of spans rather
+// than the struct size.
+func byteRows(l Layout, cols int) []byteRow {
+	var (
+		rows []byteRow
+	)
+	for _, s := range byteSpans(l) {
+		for off, end := s.offset, s.offset+s.size; off < end; {
+			if off%cols == 0 && end-off >= cols {
+				n := (end - off) / cols
+				rows = append(rows, byteRow{off, n, []byteSpan{{off, cols, s.owner}}})
+				off += n * cols
+				continue
+			}
+			if off%cols == 0 {
+				rows = append(rows, byteRow{offset: off, count: 1})
+			}
+			n := min(end, off-off%cols+cols) - off
+			r := &rows[len(rows)-1]
+			r.spans = append(r.spans, byteSpan{off, n, s.owner})
+			off += n
+		}
+	}
+	return rows
+}
+
+// rowLabel returns the offset label of a row, the
+// offset range of collapsed rows.
+func rowLabel(r byteRow, cols int) string {
+	if r.count > 1 {
+		return fmt.Sprintf("%d-%d", r.offset, r.offset+r.count*cols-1)
+	}
+	return strconv.Itoa(r.offset)
+}
+
+// cell returns the label and color of a byte owner.
+func cell(l Layout, owner int) (string, string) {
+	if owner < 0 {
+		return "pad", padcolor
+	}
+	return l.Fields[owner].Name, palette[owner%len(palette)]
+}
+
+// DotFormatter renders a Graphviz byte map with
+// `Columns` bytes per row ( 8 when 0 ); consecutive
+// bytes of a field within a row are merged, and so
+// are consecutive rows filled by a single field.
+type DotFormatter struct {
+	Columns int
+}
+
+// Format implements `Formatter`.
+func (f DotFormatter) Format(w io.Writer, l Layout) error {
+	var (
+		sb   strings.Builder
+		cols int = f.Columns
+	)
+	if cols <= 0 {
+		cols = 8
+	}
+	fmt.Fprintf(&sb, "digraph %q {\n  node [shape=plaintext];\n", l.Name)
+	sb.WriteString("  layout [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">\n")
+	fmt.Fprintf(&sb, "    <tr><td colspan=\"%d\"><b>%s</b> ( %d bytes )</td></tr>\n", cols+1, html.EscapeString(l.Name), l.Size)
+	for _, r := range byteRows(l, cols) {
+		fmt.Fprintf(&sb, "    <tr><td>%s</td>", rowLabel(r, cols))
+		for _, s := range r.spans {
+			label, color := cell(l, s.owner)
+			fmt.Fprintf(&sb, "<td colspan=\"%d\" bgcolor=\"%s\">%s</td>", s.size, color, html.EscapeString(label))
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("  </table>>];\n}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// SVGFormatter renders a standalone SVG byte map with
+// `Columns` bytes per row ( 8 when 0 ) and cells of
+// `Cell` pixels ( 48 when 0 ). Like `DotFormatter`, it
+// merges bytes of a field within a row and rows filled
+// by a single field, the latter are drawn dashed.
+type SVGFormatter struct {
+	Columns int
+	Cell    int
+}
+
+// Format implements `Formatter`.
+func (f SVGFormatter) Format(w io.Writer, l Layout) error {
+	var (
+		sb     strings.Builder
+		cols   int = f.Columns
+		size   int = f.Cell
+		margin int = 40
+		rows   []byteRow
+	)
+	if cols <= 0 {
+		cols = 8
+	}
+	if size <= 0 {
+		size = 48
+	}
+	rows = byteRows(l, cols)
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"%d\">\n",
+		margin+cols*size, margin+len(rows)*size, max(size/5, 8))
+	fmt.Fprintf(&sb, "  <text x=\"%d\" y=\"%d\">%s ( %d bytes )</text>\n", margin, margin/2, html.EscapeString(l.Name), l.Size)
+	for i, r := range rows {
+		var (
+			y    int    = margin + i*size
+			dash string = ""
+		)
+		if r.count > 1 {
+			dash = " stroke-dasharray=\"4\""
+		}
+		fmt.Fprintf(&sb, "  <text x=\"2\" y=\"%d\">%s</text>\n", y+size/2, rowLabel(r, cols))
+		for _, s := range r.spans {
+			var (
+				x int = margin + (s.offset%cols)*size
+			)
+			label, color := cell(l, s.owner)
+			if r.count > 1 {
+				label = fmt.Sprintf("%s ( %d rows )", label, r.count)
+			}
+			fmt.Fprintf(&sb, "  <rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\" stroke=\"#555\"%s><title>%s</title></rect>\n",
+				x, y, s.size*size, size, color, dash, html.EscapeString(label))
+			fmt.Fprintf(&sb, "  <text x=\"%d\" y=\"%d\">%s</text>\n", x+2, y+size/2, html.EscapeString(label))
+		}
+	}
+	sb.WriteString("</svg>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
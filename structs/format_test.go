@@ -0,0 +1,124 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormatters(t *testing.T) {
+	var (
+		buf bytes.Buffer
+	)
+	if err := Render(&buf, padded{}, JSONFormatter{Indent: "  "}); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		l Layout
+	)
+	if err := json.Unmarshal(buf.Bytes(), &l); err != nil || l.Size != 32 || l.Waste != 17 || l.Fields[1].PadBefore != 7 {
+		t.Fatalf("assertion failed, unexpected JSON %s ( %v ).", buf.String(), err)
+	}
+	buf.Reset()
+	Render(&buf, padded{}, CSVFormatter{})
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 6 || records[0][1] != "field" || records[2][1] != "B" || records[2][6] != "7" {
+		t.Fatalf("assertion failed, unexpected CSV %v ( %v ).", records, err)
+	}
+	buf.Reset()
+	Render(&buf, padded{}, MarkdownFormatter{})
+	if md := buf.String(); strings.Count(md, "\n|") != 7 || !strings.Contains(md, "| B | `int64` | 8 | 8 | 8 | 7 | 0 |") {
+		t.Fatalf("assertion failed, unexpected Markdown:\n%s", md)
+	}
+	buf.Reset()
+	Render(&buf, padded{}, DotFormatter{})
+	if dot := buf.String(); !strings.HasPrefix(dot, `digraph "padded"`) || strings.Count(dot, "<tr>") != 5 || !strings.Contains(dot, `colspan="7" bgcolor="#d9d9d9">pad`) {
+		t.Fatalf("assertion failed, unexpected DOT:\n%s", dot)
+	}
+	buf.Reset()
+	Render(&buf, padded{}, SVGFormatter{Columns: 16})
+	dec := xml.NewDecoder(&buf)
+	var (
+		rects int
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("assertion failed, invalid SVG ( %v ).", err)
+		}
+		if el, ok := tok.(xml.StartElement); ok && el.Name.Local == "rect" {
+			rects++
+		}
+	}
+	if rects != 8 {
+		t.Fatalf("expected 8 cells; got %d.", rects)
+	}
+	buf.Reset()
+	if err = Render(&buf, padded{}, FormatterFunc(func(w io.Writer, l Layout) error {
+		_, err := io.WriteString(w, l.Name)
+		return err
+	})); err != nil || buf.String() != "padded" {
+		t.Fatalf("assertion failed, unexpected custom formatter ( %v ).", err)
+	}
+	buf.Reset()
+	if Render(&buf, padded{}, TextFormatter{}); !strings.Contains(buf.String(), "<padding>") {
+		t.Fatal("assertion failed, expected text table.")
+	}
+	if err = Render(&buf, 1, TextFormatter{}); err != ENOTSTRUCT {
+		t.Fatalf("expected ENOTSTRUCT; got %v.", err)
+	}
+}
+
+type large struct {
+	A   bool
+	Buf [1 << 20]byte
+	B   int64
+}
+
+func TestFormattersLarge(t *testing.T) {
+	var (
+		buf bytes.Buffer
+	)
+	if err := Render(&buf, large{}, DotFormatter{}); err != nil {
+		t.Fatal(err)
+	}
+	if dot := buf.String(); len(dot) > 4096 || strings.Count(dot, "<tr>") != 5 || !strings.Contains(dot, "<td>8-1048575</td>") {
+		t.Fatalf("assertion failed, unexpected DOT ( %d bytes ):\n%s", len(dot), dot)
+	}
+	buf.Reset()
+	if err := Render(&buf, large{}, SVGFormatter{}); err != nil {
+		t.Fatal(err)
+	}
+	if svg := buf.String(); len(svg) > 4096 || strings.Count(svg, "<rect") != 6 || !strings.Contains(svg, "Buf ( 131071 rows )") {
+		t.Fatalf("assertion failed, unexpected SVG ( %d bytes ):\n%s", len(svg), svg)
+	}
+}
@@ -0,0 +1,286 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+)
+
+// - MARK: Layout section.
+
+// Field describes the placement of a struct field.
+type Field struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Offset    uintptr `json:"offset"`
+	Size      uintptr `json:"size"`
+	Align     uintptr `json:"align"`
+	PadBefore uintptr `json:"pad_before"`    // padding between the previous field and this field
+	PadAfter  uintptr `json:"pad_after"`     // padding between this field and the next field or the end
+	Tag       string  `json:"tag,omitempty"` // raw struct tag
+}
+
+// Layout describes the memory layout of a struct
+// along with its wasted padding and a field order
+// minimizing its size.
+type Layout struct {
+	Name            string   `json:"name"`
+	Arch            string   `json:"arch"`
+	Size            uintptr  `json:"size"`
+	Align           uintptr  `json:"align"`
+	Fields          []Field  `json:"fields"`
+	TrailingPadding uintptr  `json:"trailing_padding"`
+	Waste           uintptr  `json:"waste"`        // total padding in bytes
+	Optimal         []string `json:"optimal"`      // suggested field order
+	OptimalSize     uintptr  `json:"optimal_size"` // size with the suggested order
+	// Unaligned64 lists 64-bit integer words not 8-byte
+	// aligned, which 64-bit atomic operations reject on
+	// 32-bit architectures. Words of nested structs and
+	// arrays are listed by path such as `E[1].Y`; `[*]`
+	// stands for every element of an array.
+	Unaligned64 []string `json:"unaligned64,omitempty"`
+}
+
+// Analyze computes the layout of struct `v` for the
+// host architecture. `v` may be a struct value, a pointer
+// to a struct or its `reflect.Type`. It returns ENOTSTRUCT
+// on other types.
+func Analyze(v interface{}) (Layout, error) {
+	stype, err := structType(v)
+	if err != nil {
+		return Layout{}, err
+	}
+	var (
+		fields []Field = make([]Field, stype.NumField())
+	)
+	for i := range fields {
+		var (
+			field reflect.StructField = stype.Field(i)
+		)
+		fields[i] = Field{
+			Name:   field.Name,
+			Type:   field.Type.String(),
+			Offset: field.Offset,
+			Size:   field.Type.Size(),
+			Align:  uintptr(field.Type.Align()),
+			Tag:    string(field.Tag),
+		}
+	}
+	return newLayout(stype, runtime.GOARCH, stype.Size(), uintptr(stype.Align()), fields, nativeSizer{}), nil
+}
+
+// sizer reports field offsets and sizes of types
+// on a target architecture.
+type sizer interface {
+	offsetsof(t reflect.Type) []uintptr
+	sizeof(t reflect.Type) uintptr
+}
+
+// nativeSizer is the `sizer` of the host architecture.
+type nativeSizer struct{}
+
+func (nativeSizer) offsetsof(t reflect.Type) []uintptr {
+	var (
+		offsets []uintptr = make([]uintptr, t.NumField())
+	)
+	for i := range offsets {
+		offsets[i] = t.Field(i).Offset
+	}
+	return offsets
+}
+
+func (nativeSizer) sizeof(t reflect.Type) uintptr {
+	return t.Size()
+}
+
+// newLayout returns the layout of struct `stype` made of
+// placed `fields`, filling in padding, 64-bit alignment
+// hazards found with `s` and the optimal order.
+func newLayout(stype reflect.Type, arch string, size, align uintptr, fields []Field, s sizer) Layout {
+	var (
+		l Layout = Layout{
+			Name:   typeName(stype),
+			Arch:   arch,
+			Size:   size,
+			Align:  align,
+			Fields: fields,
+		}
+		end uintptr
+	)
+	for i := range l.Fields {
+		l.Fields[i].PadBefore = l.Fields[i].Offset - end
+		if i > 0 {
+			l.Fields[i-1].PadAfter = l.Fields[i].PadBefore
+		}
+		l.Waste += l.Fields[i].PadBefore
+		end = l.Fields[i].Offset + l.Fields[i].Size
+		l.Unaligned64 = append(l.Unaligned64, unaligned64(s, stype.Field(i).Type, l.Fields[i].Offset, l.Fields[i].Name)...)
+	}
+	l.TrailingPadding = l.Size - end
+	l.Waste += l.TrailingPadding
+	if n := len(l.Fields); n > 0 {
+		l.Fields[n-1].PadAfter = l.TrailingPadding
+	}
+	l.Optimal, l.OptimalSize = optimize(l.Fields)
+	return l
+}
+
+// unaligned64 returns paths of the 64-bit integer words
+// of type `t` placed at `offset` which are not 8-byte
+// aligned, descending into structs and arrays.
+func unaligned64(s sizer, t reflect.Type, offset uintptr, path string) []string {
+	var (
+		names []string
+	)
+	switch t.Kind() {
+	case reflect.Int64, reflect.Uint64:
+		if offset%8 != 0 {
+			names = append(names, path)
+		}
+	case reflect.Struct:
+		offsets := s.offsetsof(t)
+		for i := 0; i < t.NumField(); i++ {
+			names = append(names, unaligned64(s, t.Field(i).Type, offset+offsets[i], path+"."+t.Field(i).Name)...)
+		}
+	case reflect.Array:
+		if t.Len() == 0 || !has64(t.Elem()) {
+			break
+		}
+		esize := s.sizeof(t.Elem())
+		if t.Len() > 1 && esize%8 == 0 {
+			// every element is placed alike.
+			return unaligned64(s, t.Elem(), offset, path+"[*]")
+		}
+		for i := 0; i < t.Len(); i++ {
+			names = append(names, unaligned64(s, t.Elem(), offset+uintptr(i)*esize, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return names
+}
+
+// has64 returns whether type `t` holds a 64-bit
+// integer word.
+func has64(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int64, reflect.Uint64:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && has64(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if has64(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeName returns the name of `t`, or its
+// literal form for unnamed types.
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// alignUp rounds `x` up to a multiple of `a`.
+func alignUp(x, a uintptr) uintptr {
+	if a == 0 {
+		return x
+	}
+	return (x + a - 1) &^ (a - 1)
+}
+
+// sizeOf returns the size of a struct laying out
+// `fields` in order, following the gc rules.
+func sizeOf(fields []Field) uintptr {
+	var (
+		offset   uintptr
+		maxAlign uintptr = 1
+	)
+	for _, f := range fields {
+		offset = alignUp(offset, f.Align) + f.Size
+		maxAlign = max(maxAlign, f.Align)
+	}
+	// a trailing zero-size field must not point
+	// past the end of the struct.
+	if n := len(fields); n > 0 && fields[n-1].Size == 0 && offset > 0 {
+		offset++
+	}
+	return alignUp(offset, maxAlign)
+}
+
+// optimize returns a field order minimizing the size
+// and the resulting size. Zero-size fields come first,
+// followed by fields in decreasing alignment; sizes
+// being multiples of alignments, no padding remains
+// except for the trailing one.
+func optimize(fields []Field) ([]string, uintptr) {
+	var (
+		order []Field  = append([]Field(nil), fields...)
+		names []string = make([]string, len(fields))
+	)
+	sort.SliceStable(order, func(i, j int) bool {
+		if (order[i].Size == 0) != (order[j].Size == 0) {
+			return order[i].Size == 0
+		}
+		return order[i].Align > order[j].Align
+	})
+	for i, f := range order {
+		names[i] = f.Name
+	}
+	return names, sizeOf(order)
+}
+
+// String returns the layout as a table
+// showing padding holes.
+func (l Layout) String() string {
+	const (
+		_fmtheader = "[struct: %-16s size=%-6d align=%-4d waste=%-6d optimal=%-6d]\n"
+		_fmtbody   = "[  %-22s offset=%-6d size=%-6d align=%-4d type=%s ]\n"
+		_fmtpad    = "[  %-22s offset=%-6d size=%-6d ]\n"
+	)
+	var (
+		buff bytes.Buffer
+	)
+	fmt.Fprintf(&buff, _fmtheader, l.Name, l.Size, l.Align, l.Waste, l.OptimalSize)
+	for _, f := range l.Fields {
+		if f.PadBefore > 0 {
+			fmt.Fprintf(&buff, _fmtpad, "<padding>", f.Offset-f.PadBefore, f.PadBefore)
+		}
+		fmt.Fprintf(&buff, _fmtbody, f.Name, f.Offset, f.Size, f.Align, f.Type)
+	}
+	if l.TrailingPadding > 0 {
+		fmt.Fprintf(&buff, _fmtpad, "<padding>", l.Size-l.TrailingPadding, l.TrailingPadding)
+	}
+	for _, name := range l.Unaligned64 {
+		fmt.Fprintf(&buff, "[  unaligned 64-bit field on %s: %s ]\n", l.Arch, name)
+	}
+	return buff.String()
+}
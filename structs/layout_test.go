@@ -0,0 +1,97 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type padded struct {
+	A bool
+	B int64
+	C bool
+	D int32
+	E bool
+}
+
+type trailing struct {
+	A int64
+	B struct{}
+}
+
+func TestAnalyze(t *testing.T) {
+	l, err := Analyze(padded{})
+	if err != nil || l.Name != "padded" || l.Size != 32 || l.Align != 8 || len(l.Fields) != 5 {
+		t.Fatalf("inconsistent state %+v.", l)
+	}
+	for i, want := range []struct{ offset, before, after uintptr }{{0, 0, 7}, {8, 7, 0}, {16, 0, 3}, {20, 3, 0}, {24, 0, 7}} {
+		if f := l.Fields[i]; f.Offset != want.offset || f.PadBefore != want.before || f.PadAfter != want.after {
+			t.Errorf("expected field %s at %d with padding %d/%d; got %+v.", f.Name, want.offset, want.before, want.after, f)
+		}
+	}
+	if l.TrailingPadding != 7 || l.Waste != 17 {
+		t.Fatalf("expected trailing padding 7 and waste 17; got %d, %d.", l.TrailingPadding, l.Waste)
+	}
+	if !slices.Equal(l.Optimal, []string{"B", "D", "A", "C", "E"}) || l.OptimalSize != 16 {
+		t.Fatalf("expected optimal order [B D A C E] of size 16; got %v, %d.", l.Optimal, l.OptimalSize)
+	}
+	if !strings.Contains(l.String(), "<padding>") {
+		t.Fatal("assertion failed, expected padding rows.")
+	}
+	if p, _ := Analyze(&padded{}); p.Size != l.Size || p.Waste != l.Waste {
+		t.Fatal("assertion failed, expected pointer dereference.")
+	}
+}
+
+func TestAnalyzeZeroSize(t *testing.T) {
+	l, _ := Analyze(reflect.TypeOf(trailing{}))
+	if l.Size != 16 || l.TrailingPadding != 8 || l.OptimalSize != 8 || l.Optimal[0] != "B" {
+		t.Fatalf("inconsistent state %+v.", l)
+	}
+	// optimal orders must match the compiler layout.
+	for _, v := range []interface{}{padded{}, trailing{}, struct{}{}} {
+		l, _ = Analyze(v)
+		var (
+			fields []reflect.StructField
+			stype  reflect.Type = reflect.TypeOf(v)
+		)
+		for _, name := range l.Optimal {
+			f, _ := stype.FieldByName(name)
+			fields = append(fields, reflect.StructField{Name: f.Name, Type: f.Type})
+		}
+		if size := reflect.StructOf(fields).Size(); size != l.OptimalSize {
+			t.Fatalf("expected optimal size %d; got %d.", size, l.OptimalSize)
+		}
+	}
+}
+
+func TestAnalyzeError(t *testing.T) {
+	for _, v := range []interface{}{42, nil, new(int), []padded{}} {
+		if _, err := Analyze(v); err != ENOTSTRUCT {
+			t.Errorf("expected ENOTSTRUCT for %T; got %v.", v, err)
+		}
+	}
+}
@@ -25,33 +25,40 @@ package structs
 import (
 	"bytes"
 	"fmt"
-	"reflect"
+	"strings"
 )
 
 // CompileStructInfo is a function that takes a
 // struct `s`, generates general info table and
-// returns it as `string`.
-func CompileStructInfo(s interface{}) string {
+// returns it as `string`. Nested structs, embedded
+// structs and arrays of structs are shown as an
+// indented tree with absolute offsets. `s` may be
+// a pointer to a struct; other types yield
+// ENOTSTRUCT.
+func CompileStructInfo(s interface{}) (string, error) {
 	const (
 		_fmtheader = "[struct: %-16s%-14sfields=%-6.1dsize=%-6.2d  ]\n"
 		_fmtbody   = "[  %-22s offset=%-6.1v size=%-6.1d align=%-6d ]\n"
 	)
+	root, err := Tree(s)
+	if err != nil {
+		return "", err
+	}
 	var (
-		stype reflect.Type = reflect.TypeOf(s)
-		nf    int          = stype.NumField()
-		field reflect.StructField
-		buff  bytes.Buffer
+		buff bytes.Buffer
 	)
-	buff.WriteString(fmt.Sprintf(_fmtheader, stype.Name(), " ", nf, stype.Size()))
-	for i := 0; i < nf; i++ {
-		field = stype.Field(i)
-		buff.WriteString(
-			fmt.Sprintf(_fmtbody,
-				field.Name,
-				field.Offset,
-				field.Type.Size(),
-				field.Type.Align(),
-			))
+	buff.WriteString(fmt.Sprintf(_fmtheader, root.Name, " ", len(root.Children), root.Size))
+	for _, child := range root.Children {
+		child.Walk(func(n *Node, depth int) bool {
+			buff.WriteString(
+				fmt.Sprintf(_fmtbody,
+					strings.Repeat("  ", depth)+n.Name,
+					n.Offset,
+					n.Size,
+					n.Align,
+				))
+			return true
+		})
 	}
-	return buff.String()
+	return buff.String(), nil
 }
@@ -0,0 +1,45 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompileStructInfo(t *testing.T) {
+	info, err := CompileStructInfo(&outer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(info), "\n")
+	if !strings.Contains(lines[0], "outer") || !strings.Contains(lines[0], "fields=7") {
+		t.Fatalf("assertion failed, unexpected header %q.", lines[0])
+	}
+	if !strings.Contains(info, "[    X ") || !strings.Contains(info, "offset=12") {
+		t.Fatalf("assertion failed, expected nested fields:\n%s", info)
+	}
+	if _, err = CompileStructInfo([]int{}); err != ENOTSTRUCT {
+		t.Fatalf("expected ENOTSTRUCT; got %v.", err)
+	}
+}
@@ -0,0 +1,172 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// - MARK: Tree section.
+
+const (
+	// maxElems is the number of array elements
+	// expanded individually; the remaining ones
+	// are summarized by a single node.
+	maxElems int = 16
+)
+
+// Node is a field of the recursive layout of a
+// struct. Nested structs, embedded structs and
+// arrays of structs have children; offsets are
+// absolute, relative to the outermost struct.
+type Node struct {
+	Name     string
+	Type     string
+	Offset   uintptr
+	Size     uintptr
+	Align    uintptr
+	Embedded bool
+	Children []*Node
+}
+
+// Tree computes the recursive layout of struct `v`.
+// `v` may be a struct value, a pointer to a struct or
+// its `reflect.Type`. Pointer fields are not followed.
+// It returns ENOTSTRUCT on other types.
+func Tree(v interface{}) (*Node, error) {
+	stype, err := structType(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{
+		Name:     typeName(stype),
+		Type:     stype.String(),
+		Size:     stype.Size(),
+		Align:    uintptr(stype.Align()),
+		Children: expand(stype, 0),
+	}, nil
+}
+
+// expand returns the children of a node of type `t`
+// located at absolute offset `base`.
+func expand(t reflect.Type, base uintptr) []*Node {
+	var (
+		nodes []*Node
+	)
+	switch t.Kind() {
+	case reflect.Struct:
+		nodes = make([]*Node, t.NumField())
+		for i := range nodes {
+			var (
+				field reflect.StructField = t.Field(i)
+			)
+			nodes[i] = &Node{
+				Name:     field.Name,
+				Type:     field.Type.String(),
+				Offset:   base + field.Offset,
+				Size:     field.Type.Size(),
+				Align:    uintptr(field.Type.Align()),
+				Embedded: field.Anonymous,
+			}
+			nodes[i].Children = expand(field.Type, nodes[i].Offset)
+		}
+	case reflect.Array:
+		var (
+			elem reflect.Type = t.Elem()
+			size uintptr      = elem.Size()
+		)
+		if !hasStruct(elem) {
+			return nil
+		}
+		for i := 0; i < t.Len() && i < maxElems; i++ {
+			nodes = append(nodes, &Node{
+				Name:     fmt.Sprintf("[%d]", i),
+				Type:     elem.String(),
+				Offset:   base + uintptr(i)*size,
+				Size:     size,
+				Align:    uintptr(elem.Align()),
+				Children: expand(elem, base+uintptr(i)*size),
+			})
+		}
+		if t.Len() > maxElems {
+			nodes = append(nodes, &Node{
+				Name:   fmt.Sprintf("[%d:%d]", maxElems, t.Len()),
+				Type:   fmt.Sprintf("[%d]%s", t.Len()-maxElems, elem),
+				Offset: base + uintptr(maxElems)*size,
+				Size:   uintptr(t.Len()-maxElems) * size,
+				Align:  uintptr(elem.Align()),
+			})
+		}
+	}
+	return nodes
+}
+
+// hasStruct returns whether `t` is a struct
+// or an array of structs.
+func hasStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// Walk calls `fn` for `n` and its descendants in
+// depth-first order along with their depth; the
+// walk stops when `fn` returns false.
+func (n *Node) Walk(fn func(n *Node, depth int) bool) {
+	n.walk(fn, 0)
+}
+
+func (n *Node) walk(fn func(n *Node, depth int) bool, depth int) bool {
+	if !fn(n, depth) {
+		return false
+	}
+	for _, c := range n.Children {
+		if !c.walk(fn, depth+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the tree indented by depth.
+func (n *Node) String() string {
+	const (
+		_fmtbody = "[  %-30s offset=%-6d size=%-6d align=%-4d type=%s ]\n"
+	)
+	var (
+		buff bytes.Buffer
+	)
+	n.Walk(func(c *Node, depth int) bool {
+		name := strings.Repeat("  ", depth) + c.Name
+		if c.Embedded {
+			name += " (embedded)"
+		}
+		fmt.Fprintf(&buff, _fmtbody, name, c.Offset, c.Size, c.Align, c.Type)
+		return true
+	})
+	return buff.String()
+}
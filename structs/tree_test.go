@@ -0,0 +1,90 @@
+/* MIT License
+*
+* Copyright (c) 2018 Mike Taghavi <mitghi[at]gmail.com>
+*
+* Permission is hereby granted, free of charge, to any person obtaining a copy
+* of this software and associated documentation files (the "Software"), to deal
+* in the Software without restriction, including without limitation the rights
+* to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+* copies of the Software, and to permit persons to whom the Software is
+* furnished to do so, subject to the following conditions:
+* The above copyright notice and this permission notice shall be included in all
+* copies or substantial portions of the Software.
+*
+* THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+* IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+* FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+* AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+* LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+* OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+* SOFTWARE.
+ */
+
+package structs
+
+import (
+	"strings"
+	"testing"
+)
+
+type inner struct {
+	X int32
+	Y bool
+}
+
+type Base struct {
+	ID uint64
+}
+
+type outer struct {
+	Base
+	Flag  bool
+	In    inner
+	Pairs [2]inner
+	Many  [20]inner
+	Bytes [4]byte
+	Next  *outer
+}
+
+func TestTree(t *testing.T) {
+	root, err := Tree(&outer{})
+	if err != nil || root.Name != "outer" || len(root.Children) != 7 {
+		t.Fatalf("inconsistent state ( %v ).", err)
+	}
+	var (
+		offsets map[string]uintptr = make(map[string]uintptr)
+		path    []string
+	)
+	root.Walk(func(n *Node, depth int) bool {
+		path = append(path[:depth], n.Name)
+		offsets[strings.Join(path[1:], ".")] = n.Offset
+		return true
+	})
+	for name, want := range map[string]uintptr{
+		"Base.ID":      0,
+		"Flag":         8,
+		"In.X":         12,
+		"In.Y":         16,
+		"Pairs.[1].Y":  32,
+		"Many.[0].X":   36,
+		"Many.[16:20]": 36 + 16*8,
+		"Bytes":        196,
+		"Next":         200,
+	} {
+		if got, ok := offsets[name]; !ok || got != want {
+			t.Errorf("expected %s at %d; got %d, %t.", name, want, got, ok)
+		}
+	}
+	if !root.Children[0].Embedded || root.Children[1].Embedded {
+		t.Fatal("assertion failed, expected embedded Base.")
+	}
+	if len(root.Children[5].Children) != 0 || len(root.Children[6].Children) != 0 {
+		t.Fatal("assertion failed, expected leaf byte array and pointer.")
+	}
+	if !strings.Contains(root.String(), "    X ") || !strings.Contains(root.String(), "(embedded)") {
+		t.Fatalf("assertion failed, expected indented tree:\n%s", root)
+	}
+	if _, err := Tree(3.14); err != ENOTSTRUCT {
+		t.Fatalf("expected ENOTSTRUCT; got %v.", err)
+	}
+}